@@ -3,92 +3,45 @@ package handler
 import (
 	"context"
 	"net/http"
-	"os"
-	"strings"
-	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ghana-location-api/pkg/backend"
+	"github.com/ghana-location-api/pkg/config"
 	"github.com/ghana-location-api/pkg/handlers"
-	"github.com/ghana-location-api/pkg/repositories"
-	"github.com/ghana-location-api/pkg/services"
+	"github.com/ghana-location-api/pkg/metrics"
 )
 
 var router http.Handler
 
 func init() {
-	databaseURL := os.Getenv("DATABASE_URL")
-	if databaseURL == "" {
-		panic("DATABASE_URL environment variable is required")
-	}
-
-	// Trim any whitespace that might have been accidentally included
-	databaseURL = strings.TrimSpace(databaseURL)
-
-	pool, err := pgxpool.New(context.Background(), databaseURL)
+	cfg, err := config.Load()
 	if err != nil {
-		panic("failed to create database pool: " + err.Error())
+		panic("failed to load config: " + err.Error())
 	}
 
-	// Test connection with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if err := pool.Ping(ctx); err != nil {
-		panic("failed to ping database: " + err.Error())
+	// Initialize services backed by the configured data source. The
+	// backend lives for the lifetime of the function instance.
+	b, err := backend.New(context.Background(), cfg)
+	if err != nil {
+		panic("failed to initialize " + cfg.DataSource + " data source: " + err.Error())
 	}
+	locationService := b.Service
 
-	// Initialize repositories
-	countryRepo := repositories.NewCountryRepository(pool)
-	regionRepo := repositories.NewRegionRepository(pool)
-	districtRepo := repositories.NewDistrictRepository(pool)
-	constituencyRepo := repositories.NewConstituencyRepository(pool)
-	cityRepo := repositories.NewCityRepository(pool)
-
-	// Initialize services
-	locationService := services.NewLocationService(
-		countryRepo,
-		regionRepo,
-		districtRepo,
-		constituencyRepo,
-		cityRepo,
-	)
-
-	// Initialize handlers
-	countryHandler := handlers.NewCountryHandler(locationService)
-	regionHandler := handlers.NewRegionHandler(locationService)
-	districtHandler := handlers.NewDistrictHandler(locationService)
-	constituencyHandler := handlers.NewConstituencyHandler(locationService)
-	cityHandler := handlers.NewCityHandler(locationService)
+	requestMetrics := metrics.New(b.Pool, b.Cache)
 
 	// Setup router
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
+	r.Use(requestMetrics.Middleware)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
+	r.NotFound(handlers.NotFound)
+	r.MethodNotAllowed(handlers.MethodNotAllowed)
 
 	// API routes
-	r.Route("/api/v1", func(r chi.Router) {
-		// Countries
-		r.Get("/countries", countryHandler.GetAll)
-		r.Get("/countries/{code}", countryHandler.GetByCode)
-
-		// Regions
-		r.Get("/regions", regionHandler.GetAll)
-		r.Get("/regions/{slug}", regionHandler.GetBySlug)
-		r.Get("/regions/{slug}/districts", regionHandler.GetDistricts)
-
-		// Districts
-		r.Get("/districts/{slug}", districtHandler.GetBySlug)
-		r.Get("/districts/{slug}/constituencies", districtHandler.GetConstituencies)
-
-		// Constituencies
-		r.Get("/constituencies/{slug}", constituencyHandler.GetBySlug)
-
-		// Cities
-		r.Get("/cities", cityHandler.GetByDistrict)
-	})
+	r.Route("/api/v1", handlers.Routes(locationService))
 
 	// Health check
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -96,6 +49,9 @@ func init() {
 		w.Write([]byte("OK"))
 	})
 
+	// Prometheus metrics
+	r.Method(http.MethodGet, "/metrics", requestMetrics)
+
 	router = r
 }
 
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+)
+
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+type Feature struct {
+	Properties map[string]any  `json:"properties"`
+	Geometry   json.RawMessage `json:"geometry"`
+}
+
+// Boundary tables that can be imported, keyed by -level.
+var boundaryTables = map[string]string{
+	"region":   "regions",
+	"district": "districts",
+}
+
+func main() {
+	level := flag.String("level", "", "boundary level to import: region or district")
+	file := flag.String("file", "", "path to a GeoJSON FeatureCollection")
+	property := flag.String("property", "slug", "feature property holding the region/district slug or name")
+	flag.Parse()
+
+	table, ok := boundaryTables[*level]
+	if !ok || *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load .env file if it exists
+	_ = godotenv.Load()
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatalf("DATABASE_URL environment variable is required")
+	}
+
+	pool, err := pgxpool.New(context.Background(), databaseURL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+
+	// Test connection
+	if err := pool.Ping(ctx); err != nil {
+		log.Fatalf("failed to ping database: %v", err)
+	}
+
+	fmt.Println("✓ Connected to database successfully")
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", *file, err)
+	}
+
+	var collection FeatureCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		log.Fatalf("failed to parse %s: %v", *file, err)
+	}
+	if collection.Type != "FeatureCollection" {
+		log.Fatalf("%s is not a GeoJSON FeatureCollection", *file)
+	}
+
+	fmt.Printf("\nImporting %s boundaries from %s...\n", *level, *file)
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		log.Fatalf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	imported := 0
+	for i, feature := range collection.Features {
+		value, _ := feature.Properties[*property].(string)
+		if value == "" {
+			fmt.Printf("  ⚠ Feature %d has no %q property\n", i, *property)
+			continue
+		}
+
+		// Match on slug first, then on the name with or without the type suffix
+		// ("Ahafo" matches "Ahafo Region").
+		tag, err := tx.Exec(ctx, fmt.Sprintf(`
+			UPDATE %s
+			SET boundary = ST_Multi(ST_SetSRID(ST_GeomFromGeoJSON($2), 4326))
+			WHERE slug = $1
+			   OR lower(name) = lower($1)
+			   OR lower(name) = lower($1 || ' %s')
+		`, table, *level), strings.TrimSpace(value), string(feature.Geometry))
+		if err != nil {
+			log.Fatalf("failed to import boundary for %s: %v", value, err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			fmt.Printf("  ⚠ No %s found for feature %q\n", *level, value)
+			continue
+		}
+		imported++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Fatalf("failed to commit transaction: %v", err)
+	}
+
+	fmt.Printf("✓ %d of %d %s boundaries imported\n", imported, len(collection.Features), *level)
+}
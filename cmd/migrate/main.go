@@ -2,15 +2,47 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5"
 	"github.com/joho/godotenv"
 )
 
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: migrate [flags] [command]
+
+Commands:
+  up        apply all pending migrations (default)
+  down      roll back the last -steps applied migrations
+  redo      roll back the last applied migration and apply it again
+  status    list migrations and whether they have been applied
+  baseline  record migrations up to -version as applied without running them,
+            for databases created before migrations were tracked
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
 func main() {
+	dir := flag.String("dir", "migrations", "directory holding the numbered migration files")
+	steps := flag.Int("steps", 1, "number of migrations to roll back with down")
+	version := flag.Int64("version", 0, "last migration version to record with baseline")
+	flag.Usage = usage
+	flag.Parse()
+
+	command := "up"
+	if flag.NArg() > 0 {
+		command = flag.Arg(0)
+	}
+	if flag.NArg() > 1 || (command == "baseline" && *version <= 0) || *steps < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
 	// Load .env file if it exists
 	_ = godotenv.Load()
 
@@ -19,102 +51,65 @@ func main() {
 		log.Fatalf("DATABASE_URL environment variable is required")
 	}
 
-	pool, err := pgxpool.New(context.Background(), databaseURL)
-	if err != nil {
-		log.Fatalf("failed to connect to database: %v", err)
-	}
-	defer pool.Close()
-
-	// Test connection
-	if err := pool.Ping(context.Background()); err != nil {
-		log.Fatalf("failed to ping database: %v", err)
-	}
-
-	fmt.Println("✓ Connected to database successfully")
-
-	// Read migration file
-	migrationPath := "migrations/001_initial_schema.sql"
-	migrationSQL, err := os.ReadFile(migrationPath)
+	migrations, err := loadMigrations(*dir)
 	if err != nil {
-		log.Fatalf("failed to read migration file: %v", err)
+		log.Fatalf("%v", err)
 	}
 
-	// Execute migration as a single transaction
+	// Migrations run on a single connection, which holds the advisory lock.
 	ctx := context.Background()
-	tx, err := pool.Begin(ctx)
+	conn, err := pgx.Connect(ctx, databaseURL)
 	if err != nil {
-		log.Fatalf("failed to begin transaction: %v", err)
+		log.Fatalf("failed to connect to database: %v", err)
 	}
-	defer tx.Rollback(ctx)
+	defer conn.Close(ctx)
 
-	// Execute the entire migration
-	_, err = tx.Exec(ctx, string(migrationSQL))
-	if err != nil {
-		log.Fatalf("failed to execute migration: %v", err)
-	}
+	fmt.Println("✓ Connected to database successfully")
 
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
-		log.Fatalf("failed to commit transaction: %v", err)
+	migrator := &Migrator{conn: conn, migrations: migrations}
+	if err := migrator.lock(ctx); err != nil {
+		log.Fatalf("%v", err)
 	}
+	defer migrator.unlock(ctx)
 
-	fmt.Println("✓ Migration executed successfully")
+	if err := migrator.ensureTable(ctx); err != nil {
+		log.Fatalf("failed to create schema_migrations table: %v", err)
+	}
 
-	// Verify tables were created
-	tables := []string{"countries", "regions", "districts", "constituencies", "cities"}
-	for _, table := range tables {
-		var exists bool
-		err := pool.QueryRow(ctx, 
-			"SELECT EXISTS (SELECT FROM information_schema.tables WHERE table_schema = 'public' AND table_name = $1)",
-			table,
-		).Scan(&exists)
-		
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
 		if err != nil {
-			log.Fatalf("failed to check table %s: %v", table, err)
+			log.Fatalf("%v", err)
 		}
-
-		if exists {
-			fmt.Printf("✓ Table '%s' exists\n", table)
+		if applied == 0 {
+			fmt.Println("✓ Database is up to date")
 		} else {
-			log.Fatalf("✗ Table '%s' does not exist", table)
+			fmt.Printf("✓ %d migration(s) applied\n", applied)
 		}
-	}
-
-	// Verify indexes
-	indexes := []string{
-		"idx_regions_country_id",
-		"idx_regions_slug",
-		"idx_districts_region_id",
-		"idx_districts_slug",
-		"idx_constituencies_district_id",
-		"idx_constituencies_slug",
-		"idx_cities_district_id",
-	}
-
-	for _, idx := range indexes {
-		var exists bool
-		err := pool.QueryRow(ctx,
-			"SELECT EXISTS (SELECT FROM pg_indexes WHERE indexname = $1)",
-			idx,
-		).Scan(&exists)
-
+	case "down":
+		rolledBack, err := migrator.Down(ctx, *steps)
 		if err != nil {
-			log.Fatalf("failed to check index %s: %v", idx, err)
+			log.Fatalf("%v", err)
 		}
-
-		if exists {
-			fmt.Printf("✓ Index '%s' exists\n", idx)
-		} else {
-			fmt.Printf("⚠ Index '%s' not found (may be created automatically)\n", idx)
+		fmt.Printf("✓ %d migration(s) rolled back\n", rolledBack)
+	case "redo":
+		if err := migrator.Redo(ctx); err != nil {
+			log.Fatalf("%v", err)
 		}
+	case "status":
+		if err := migrator.Status(ctx); err != nil {
+			log.Fatalf("failed to read migration status: %v", err)
+		}
+	case "baseline":
+		recorded, err := migrator.Baseline(ctx, *version)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		fmt.Printf("✓ %d migration(s) recorded\n", recorded)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", command)
+		flag.Usage()
+		os.Exit(2)
 	}
-
-	fmt.Println("\n✓ All migrations tested successfully!")
-}
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
 }
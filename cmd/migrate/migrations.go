@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// migrationLockKey identifies the advisory lock held while migrating, so two
+// deploys cannot migrate the same database at once.
+const migrationLockKey = 7_246_372_001
+
+// migrationFile matches "001_initial_schema.sql" (up) and
+// "001_initial_schema.down.sql" (down).
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+?)(\.down)?\.sql$`)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	UpPath   string
+	DownPath string // empty when the migration cannot be rolled back
+	UpSQL    string
+	Checksum string // sha256 of UpSQL
+}
+
+type AppliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// loadMigrations discovers the numbered migrations in dir, ordered by version.
+func loadMigrations(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %03d has files with different names: %s and %s", version, m.Name, match[2])
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		if match[3] != "" {
+			m.DownPath = path
+			continue
+		}
+		if m.UpPath != "" {
+			return nil, fmt.Errorf("duplicate migration %03d: %s and %s", version, m.UpPath, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file: %w", err)
+		}
+		sum := sha256.Sum256(data)
+		m.UpPath = path
+		m.UpSQL = string(data)
+		m.Checksum = hex.EncodeToString(sum[:])
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpPath == "" {
+			return nil, fmt.Errorf("migration %03d_%s has a down file but no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Migrator applies migrations over a single connection holding the migration lock.
+type Migrator struct {
+	conn       *pgx.Conn
+	migrations []Migration
+}
+
+// lock takes the migration advisory lock, waiting for any other migration to finish.
+func (m *Migrator) lock(ctx context.Context) error {
+	var acquired bool
+	if err := m.conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", migrationLockKey).Scan(&acquired); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	if acquired {
+		return nil
+	}
+
+	fmt.Println("… Another migration is running, waiting for it to finish")
+	if _, err := m.conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	return nil
+}
+
+func (m *Migrator) unlock(ctx context.Context) error {
+	_, err := m.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey)
+	return err
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR NOT NULL,
+			checksum VARCHAR(64) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`)
+	return err
+}
+
+// applied returns the recorded migrations, ordered by version.
+func (m *Migrator) applied(ctx context.Context) ([]AppliedMigration, error) {
+	rows, err := m.conn.Query(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var applied []AppliedMigration
+	for rows.Next() {
+		var a AppliedMigration
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied = append(applied, a)
+	}
+	return applied, rows.Err()
+}
+
+// verify fails if an applied migration's file was edited or removed.
+func (m *Migrator) verify(applied []AppliedMigration) error {
+	for _, a := range applied {
+		migration := m.find(a.Version)
+		if migration == nil {
+			return fmt.Errorf("applied migration %03d_%s is missing from the migrations directory", a.Version, a.Name)
+		}
+		if migration.Checksum != a.Checksum {
+			return fmt.Errorf("migration %s has been edited since it was applied; add a new migration instead", migration.UpPath)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// pending returns the migrations not yet applied, in order.
+func (m *Migrator) pending(applied []AppliedMigration) []Migration {
+	done := make(map[int64]bool, len(applied))
+	for _, a := range applied {
+		done[a.Version] = true
+	}
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if !done[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// Up applies every pending migration, each in its own transaction.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := m.verify(applied); err != nil {
+		return 0, err
+	}
+
+	pending := m.pending(applied)
+	for _, migration := range pending {
+		if err := m.apply(ctx, migration); err != nil {
+			return 0, err
+		}
+	}
+	return len(pending), nil
+}
+
+func (m *Migrator) apply(ctx context.Context, migration Migration) error {
+	tx, err := m.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, migration.UpSQL); err != nil {
+		return fmt.Errorf("failed to execute migration %s: %w", migration.UpPath, err)
+	}
+	if _, err := tx.Exec(ctx,
+		"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+		migration.Version, migration.Name, migration.Checksum,
+	); err != nil {
+		return fmt.Errorf("failed to record migration %03d: %w", migration.Version, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit migration %03d: %w", migration.Version, err)
+	}
+
+	fmt.Printf("✓ Applied %03d_%s\n", migration.Version, migration.Name)
+	return nil
+}
+
+// Down rolls back the last steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := m.verify(applied); err != nil {
+		return 0, err
+	}
+
+	rolledBack := 0
+	for i := len(applied) - 1; i >= 0 && rolledBack < steps; i-- {
+		if err := m.revert(ctx, *m.find(applied[i].Version)); err != nil {
+			return rolledBack, err
+		}
+		rolledBack++
+	}
+	return rolledBack, nil
+}
+
+func (m *Migrator) revert(ctx context.Context, migration Migration) error {
+	if migration.DownPath == "" {
+		return fmt.Errorf("migration %03d_%s has no down migration", migration.Version, migration.Name)
+	}
+	downSQL, err := os.ReadFile(migration.DownPath)
+	if err != nil {
+		return fmt.Errorf("failed to read migration file: %w", err)
+	}
+
+	tx, err := m.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, string(downSQL)); err != nil {
+		return fmt.Errorf("failed to execute migration %s: %w", migration.DownPath, err)
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+		return fmt.Errorf("failed to unrecord migration %03d: %w", migration.Version, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit migration %03d: %w", migration.Version, err)
+	}
+
+	fmt.Printf("✓ Rolled back %03d_%s\n", migration.Version, migration.Name)
+	return nil
+}
+
+// Redo rolls back the latest applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		return fmt.Errorf("no migrations have been applied")
+	}
+	if err := m.verify(applied); err != nil {
+		return err
+	}
+
+	migration := *m.find(applied[len(applied)-1].Version)
+	if err := m.revert(ctx, migration); err != nil {
+		return err
+	}
+	return m.apply(ctx, migration)
+}
+
+// Baseline records every migration up to version as applied without running
+// it, for databases created before migrations were tracked.
+func (m *Migrator) Baseline(ctx context.Context, version int64) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	recorded := 0
+	for _, migration := range m.pending(applied) {
+		if migration.Version > version {
+			break
+		}
+		if _, err := m.conn.Exec(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum,
+		); err != nil {
+			return recorded, fmt.Errorf("failed to record migration %03d: %w", migration.Version, err)
+		}
+		fmt.Printf("✓ Recorded %03d_%s as applied\n", migration.Version, migration.Name)
+		recorded++
+	}
+	return recorded, nil
+}
+
+// Status prints every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	byVersion := make(map[int64]AppliedMigration, len(applied))
+	for _, a := range applied {
+		byVersion[a.Version] = a
+	}
+
+	fmt.Printf("\n%-8s %-32s %-10s %s\n", "VERSION", "NAME", "STATUS", "APPLIED AT")
+	for _, migration := range m.migrations {
+		status, appliedAt := "pending", ""
+		if a, ok := byVersion[migration.Version]; ok {
+			status, appliedAt = "applied", a.AppliedAt.Local().Format(time.DateTime)
+			if a.Checksum != migration.Checksum {
+				status = "modified"
+			}
+		}
+		fmt.Printf("%03d      %-32s %-10s %s\n", migration.Version, migration.Name, status, appliedAt)
+	}
+	for _, a := range applied {
+		if m.find(a.Version) == nil {
+			fmt.Printf("%03d      %-32s %-10s %s\n", a.Version, a.Name, "missing", a.AppliedAt.Local().Format(time.DateTime))
+		}
+	}
+	return nil
+}
@@ -2,51 +2,35 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/ghana-location-api/pkg/dataset"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 )
 
-type CountryData struct {
-	Code string `json:"code"`
-	Name string `json:"name"`
-	Slug string `json:"slug"`
-}
-
-type RegionData struct {
-	Name    string  `json:"name"`
-	Slug    string  `json:"slug"`
-	Capital *string `json:"capital,omitempty"`
-}
+// seedTables lists the seeded tables, parents before children.
+var seedTables = []string{"countries", "regions", "districts", "district_regions", "constituencies", "cities", "polling_stations", "lineage", "alternate_names", "localized_names"}
 
-type DistrictData struct {
-	Name       string  `json:"name"`
-	Slug       string  `json:"slug"`
-	Type       string  `json:"type"`
-	Capital    *string `json:"capital,omitempty"`
-	RegionSlug string  `json:"region_slug"`
-}
-
-type ConstituencyData struct {
-	Name        string  `json:"name"`
-	Slug        string  `json:"slug"`
-	RegionSlug  string  `json:"region_slug"`
-	DistrictSlug *string `json:"district_slug,omitempty"`
-}
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report the changes without writing them")
+	only := flag.String("only", "", "comma-separated tables to seed (default all): "+strings.Join(seedTables, ", "))
+	flag.Parse()
 
-type CityData struct {
-	Name        string   `json:"name"`
-	Slug        string   `json:"slug"`
-	Lat         *float64 `json:"lat,omitempty"`
-	Lng         *float64 `json:"lng,omitempty"`
-	DistrictSlug string  `json:"district_slug"`
-}
+	tables, err := parseTables(*only)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
 
-func main() {
 	// Load .env file if it exists
 	_ = godotenv.Load()
 
@@ -70,216 +54,377 @@ func main() {
 
 	fmt.Println("✓ Connected to database successfully")
 
-	// Seed countries
-	fmt.Println("\nSeeding countries...")
-	if err := seedCountries(ctx, pool); err != nil {
-		log.Fatalf("failed to seed countries: %v", err)
+	// Load data files
+	dataFS := os.DirFS("data")
+	ds, err := dataset.Load(dataFS)
+	if err != nil {
+		log.Fatalf("failed to load data: %v", err)
 	}
-	fmt.Println("✓ Countries seeded")
 
-	// Seed regions
-	fmt.Println("\nSeeding regions...")
-	regionMap, err := seedRegions(ctx, pool)
-	if err != nil {
-		log.Fatalf("failed to seed regions: %v", err)
+	var stations []dataset.PollingStation
+	if tables["polling_stations"] {
+		stations, err = dataset.LoadPollingStations(dataFS, ds)
+		if err != nil {
+			log.Fatalf("failed to load polling stations: %v", err)
+		}
 	}
-	fmt.Printf("✓ Regions seeded (%d regions)\n", len(regionMap))
 
-	// Seed districts
-	fmt.Println("\nSeeding districts...")
-	districtMap, err := seedDistricts(ctx, pool, regionMap)
+	// Everything is seeded in one transaction, so a failure part way leaves
+	// the database untouched. A dry run rolls the transaction back.
+	tx, err := pool.Begin(ctx)
 	if err != nil {
-		log.Fatalf("failed to seed districts: %v", err)
+		log.Fatalf("failed to begin transaction: %v", err)
 	}
-	fmt.Printf("✓ Districts seeded (%d districts)\n", len(districtMap))
+	defer tx.Rollback(ctx)
 
-	// Seed constituencies
-	fmt.Println("\nSeeding constituencies...")
-	if err := seedConstituencies(ctx, pool, districtMap); err != nil {
-		log.Fatalf("failed to seed constituencies: %v", err)
+	s := &seeder{tx: tx, ds: ds, stations: stations, diffs: make(map[string]syncDiff)}
+	for _, table := range seedTables {
+		if err := s.seed(ctx, table, tables[table]); err != nil {
+			log.Fatalf("failed to seed %s: %v", table, err)
+		}
 	}
-	fmt.Println("✓ Constituencies seeded")
 
-	// Seed cities
-	fmt.Println("\nSeeding cities...")
-	if err := seedCities(ctx, pool, districtMap); err != nil {
-		log.Fatalf("failed to seed cities: %v", err)
+	printDiffs(s.diffs)
+
+	// The version digests every data file. After a seed of some tables only
+	// those hold that version, so they are recorded with it.
+	version, err := dataset.Version(dataFS)
+	if err != nil {
+		log.Fatalf("failed to compute dataset version: %v", err)
+	}
+	var seeded []string
+	if *only != "" {
+		for _, table := range seedTables {
+			if tables[table] {
+				seeded = append(seeded, table)
+			}
+		}
+	}
+	recorded, err := recordVersion(ctx, tx, version, seeded, s.diffs)
+	if err != nil {
+		log.Fatalf("failed to record dataset version: %v", err)
 	}
-	fmt.Println("✓ Cities seeded")
 
-	fmt.Println("\n✓ Database seeding completed successfully!")
-}
+	if *dryRun {
+		fmt.Println("\n✓ Dry run: no changes written")
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		log.Fatalf("failed to commit transaction: %v", err)
+	}
 
-func seedCountries(ctx context.Context, pool *pgxpool.Pool) error {
-	data, err := os.ReadFile("data/countries.json")
-	if err != nil {
-		return fmt.Errorf("failed to read countries.json: %w", err)
+	if recorded && seeded != nil {
+		fmt.Printf("✓ Dataset version %s recorded for %s\n", version, strings.Join(seeded, ", "))
+	} else if recorded {
+		fmt.Printf("✓ Dataset version %s recorded\n", version)
 	}
+	fmt.Println("\n✓ Database seeding completed successfully!")
+}
 
-	var countries []CountryData
-	if err := json.Unmarshal(data, &countries); err != nil {
-		return fmt.Errorf("failed to parse countries.json: %w", err)
+// recordVersion adds version to dataset_versions when the seed changed any
+// rows, so the API's ETags, cache keys and Last-Modified dates move on. A
+// full seed is also recorded when the latest row has another version or
+// was a seed of some tables. tables lists the tables seeded, and is nil for
+// a full seed. It reports whether a row was added.
+func recordVersion(ctx context.Context, tx pgx.Tx, version string, tables []string, diffs map[string]syncDiff) (bool, error) {
+	changed := false
+	for _, diff := range diffs {
+		changed = changed || diff.Inserted+diff.Updated+diff.Deleted+diff.Retired > 0
 	}
 
-	for _, country := range countries {
-		_, err := pool.Exec(ctx,
-			"INSERT INTO countries (code, name) VALUES ($1, $2) ON CONFLICT (code) DO NOTHING",
-			country.Code, country.Name,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to insert country %s: %w", country.Code, err)
+	if !changed {
+		if tables != nil {
+			return false, nil
+		}
+		var latest string
+		var full bool
+		err := tx.QueryRow(ctx, "SELECT version, tables IS NULL FROM dataset_versions ORDER BY id DESC LIMIT 1").Scan(&latest, &full)
+		if err != nil && err != pgx.ErrNoRows {
+			return false, err
+		}
+		if latest == version && full {
+			return false, nil
 		}
 	}
 
-	return nil
+	_, err := tx.Exec(ctx, "INSERT INTO dataset_versions (version, tables) VALUES ($1, $2)", version, tables)
+	return err == nil, err
 }
 
-func seedRegions(ctx context.Context, pool *pgxpool.Pool) (map[string]string, error) {
-	data, err := os.ReadFile("data/regions.json")
-	if err != nil {
-		return nil, fmt.Errorf("failed to read regions.json: %w", err)
-	}
-
-	var regions []RegionData
-	if err := json.Unmarshal(data, &regions); err != nil {
-		return nil, fmt.Errorf("failed to parse regions.json: %w", err)
+// parseTables reads the -only flag. An empty value selects every table.
+func parseTables(only string) (map[string]bool, error) {
+	tables := make(map[string]bool)
+	if only == "" {
+		for _, table := range seedTables {
+			tables[table] = true
+		}
+		return tables, nil
 	}
 
-	// Get country ID for Ghana
-	var countryID string
-	err = pool.QueryRow(ctx, "SELECT id FROM countries WHERE code = 'GH'").Scan(&countryID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get country ID for GH: %w", err)
+	for _, table := range strings.Split(only, ",") {
+		table = strings.TrimSpace(table)
+		if !slices.Contains(seedTables, table) {
+			return nil, fmt.Errorf("unknown table: %s", table)
+		}
+		tables[table] = true
 	}
+	return tables, nil
+}
 
-	regionMap := make(map[string]string)
+// seeder syncs the tables in order. The ids of each parent table are read
+// back after it is seeded (or straight from the database when it is skipped)
+// so child rows can reference them.
+type seeder struct {
+	tx       pgx.Tx
+	ds       *dataset.Dataset
+	stations []dataset.PollingStation
+	diffs    map[string]syncDiff
+
+	countryID       string
+	regionMap       map[string]string
+	districtMap     map[string]string
+	constituencyMap map[string]string
+}
 
-	for _, region := range regions {
-		var regionID string
-		err := pool.QueryRow(ctx,
-			`INSERT INTO regions (country_id, name, slug, capital) 
-			 VALUES ($1, $2, $3, $4) 
-			 ON CONFLICT (slug) DO UPDATE SET name = EXCLUDED.name, capital = EXCLUDED.capital
-			 RETURNING id`,
-			countryID, region.Name, region.Slug, region.Capital,
-		).Scan(&regionID)
+func (s *seeder) seed(ctx context.Context, table string, selected bool) error {
+	if selected {
+		fmt.Printf("Seeding %s...\n", table)
+		var sync tableSync
+		switch table {
+		case "countries":
+			sync = countriesSync(s.ds.Countries)
+		case "regions":
+			sync = regionsSync(s.ds.Regions, s.countryID)
+		case "districts":
+			sync = districtsSync(s.ds.Districts, s.regionMap)
+		case "district_regions":
+			sync = districtRegionsSync(s.ds.DistrictRegions(), s.regionMap, s.districtMap)
+		case "constituencies":
+			sync = constituenciesSync(s.ds, s.regionMap, s.districtMap)
+		case "cities":
+			existing, err := citySlugs(ctx, s.tx)
+			if err != nil {
+				return fmt.Errorf("failed to read city slugs: %w", err)
+			}
+			sync = citiesSync(s.ds, s.districtMap, existing)
+		case "polling_stations":
+			sync = pollingStationsSync(s.stations, s.regionMap, s.districtMap, s.constituencyMap)
+		case "lineage":
+			sync = lineageSync(s.ds.LineageLinks())
+		case "alternate_names":
+			sync = alternateNamesSync(s.ds.AlternateNames)
+		case "localized_names":
+			sync = localizedNamesSync(s.ds.LocalizedNames)
+		}
 
+		diff, err := syncTable(ctx, s.tx, sync)
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert region %s: %w", region.Slug, err)
+			return err
 		}
+		s.diffs[table] = diff
+	}
 
-		regionMap[region.Slug] = regionID
+	var err error
+	switch table {
+	case "countries":
+		err = s.tx.QueryRow(ctx, "SELECT id FROM countries WHERE code = 'GH'").Scan(&s.countryID)
+		if err != nil {
+			err = fmt.Errorf("failed to get country ID for GH: %w", err)
+		}
+	case "regions":
+		s.regionMap, err = idsBySlug(ctx, s.tx, "regions")
+	case "districts":
+		s.districtMap, err = idsBySlug(ctx, s.tx, "districts")
+	case "constituencies":
+		s.constituencyMap, err = idsBySlug(ctx, s.tx, "constituencies")
 	}
+	return err
+}
 
-	return regionMap, nil
+func printDiffs(diffs map[string]syncDiff) {
+	fmt.Printf("\n%-18s %10s %10s %10s %10s %10s\n", "TABLE", "INSERTED", "UPDATED", "UNCHANGED", "DELETED", "RETIRED")
+	for _, table := range seedTables {
+		diff, ok := diffs[table]
+		if !ok {
+			continue
+		}
+		fmt.Printf("%-18s %10d %10d %10d %10d %10d\n", table, diff.Inserted, diff.Updated, diff.Unchanged, diff.Deleted, diff.Retired)
+	}
 }
 
-func seedDistricts(ctx context.Context, pool *pgxpool.Pool, regionMap map[string]string) (map[string]string, error) {
-	data, err := os.ReadFile("data/districts.json")
-	if err != nil {
-		return nil, fmt.Errorf("failed to read districts.json: %w", err)
+func countriesSync(countries []dataset.Country) tableSync {
+	s := tableSync{table: "countries", keys: []string{"code"}, columns: []string{"name"}}
+	for _, country := range countries {
+		s.rows = append(s.rows, []any{country.Code, country.Name})
 	}
+	return s
+}
 
-	var districts []DistrictData
-	if err := json.Unmarshal(data, &districts); err != nil {
-		return nil, fmt.Errorf("failed to parse districts.json: %w", err)
+func regionsSync(regions []dataset.Region, countryID string) tableSync {
+	s := tableSync{
+		table:   "regions",
+		keys:    []string{"slug"},
+		columns: []string{"country_id", "name", "capital", "ec_letter", "valid_from", "valid_to"},
+		retire:  true,
 	}
+	for _, region := range regions {
+		from, to, ok := validity(region.Slug, region.Validity)
+		if !ok {
+			continue
+		}
 
-	districtMap := make(map[string]string)
+		// Retired regions may have no EC letter
+		var ecLetter any
+		if region.ECLetter != "" {
+			ecLetter = region.ECLetter
+		}
+		s.rows = append(s.rows, []any{region.Slug, countryID, region.Name, region.Capital, ecLetter, from, to})
+	}
+	return s
+}
 
+func districtsSync(districts []dataset.District, regionMap map[string]string) tableSync {
+	s := tableSync{
+		table:   "districts",
+		keys:    []string{"slug"},
+		columns: []string{"region_id", "name", "type", "capital", "valid_from", "valid_to"},
+		retire:  true,
+	}
 	for _, district := range districts {
 		regionID, exists := regionMap[district.RegionSlug]
 		if !exists {
-			return nil, fmt.Errorf("region not found: %s", district.RegionSlug)
+			// Log warning but continue
+			fmt.Printf("  ⚠ Region not found for district %s: %s\n", district.Slug, district.RegionSlug)
+			continue
 		}
-
-		var districtID string
-		err := pool.QueryRow(ctx,
-			`INSERT INTO districts (region_id, name, slug, type, capital) 
-			 VALUES ($1, $2, $3, $4, $5) 
-			 ON CONFLICT (slug) DO UPDATE SET name = EXCLUDED.name, type = EXCLUDED.type, capital = EXCLUDED.capital
-			 RETURNING id`,
-			regionID, district.Name, district.Slug, district.Type, district.Capital,
-		).Scan(&districtID)
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to insert district %s: %w", district.Slug, err)
+		from, to, ok := validity(district.Slug, district.Validity)
+		if !ok {
+			continue
 		}
-
-		districtMap[district.Slug] = districtID
+		s.rows = append(s.rows, []any{district.Slug, regionID, district.Name, district.Type, district.Capital, from, to})
 	}
-
-	return districtMap, nil
+	return s
 }
 
-func seedConstituencies(ctx context.Context, pool *pgxpool.Pool, districtMap map[string]string) error {
-	data, err := os.ReadFile("data/constituencies.json")
-	if err != nil {
-		return fmt.Errorf("failed to read constituencies.json: %w", err)
+func districtRegionsSync(periods []dataset.DistrictRegion, regionMap, districtMap map[string]string) tableSync {
+	s := tableSync{table: "district_regions", keys: []string{"district_id", "region_id"}, columns: []string{"valid_from", "valid_to"}}
+	for _, period := range periods {
+		districtID, exists := districtMap[period.DistrictSlug]
+		if !exists {
+			// Log warning but continue
+			fmt.Printf("  ⚠ District not found for region of %s\n", period.DistrictSlug)
+			continue
+		}
+		regionID, exists := regionMap[period.RegionSlug]
+		if !exists {
+			// Log warning but continue
+			fmt.Printf("  ⚠ Region not found for district %s: %s\n", period.DistrictSlug, period.RegionSlug)
+			continue
+		}
+		from, to, ok := validity(period.DistrictSlug, period.Validity)
+		if !ok {
+			continue
+		}
+		s.rows = append(s.rows, []any{districtID, regionID, from, to})
 	}
+	return s
+}
 
-	var constituencies []ConstituencyData
-	if err := json.Unmarshal(data, &constituencies); err != nil {
-		return fmt.Errorf("failed to parse constituencies.json: %w", err)
+func constituenciesSync(ds *dataset.Dataset, regionMap, districtMap map[string]string) tableSync {
+	s := tableSync{
+		table:   "constituencies",
+		keys:    []string{"slug"},
+		columns: []string{"region_id", "district_id", "name", "ec_number", "valid_from", "valid_to"},
+		retire:  true,
 	}
+	regions := ds.ConstituencyRegions()
+	for _, constituency := range ds.Constituencies {
+		regionID, exists := regionMap[regions[constituency.Slug]]
+		if !exists {
+			// Log warning but continue
+			fmt.Printf("  ⚠ Region not found for constituency %s: %s\n", constituency.Slug, regions[constituency.Slug])
+			continue
+		}
 
-	for _, constituency := range constituencies {
-		var districtID *string
+		var districtID any
 		if constituency.DistrictSlug != nil {
 			id, exists := districtMap[*constituency.DistrictSlug]
 			if !exists {
 				// Log warning but continue
 				fmt.Printf("  ⚠ District not found for constituency %s: %s\n", constituency.Slug, *constituency.DistrictSlug)
 			} else {
-				districtID = &id
+				districtID = id
 			}
 		}
-
-		_, err := pool.Exec(ctx,
-			`INSERT INTO constituencies (district_id, name, slug) 
-			 VALUES ($1, $2, $3) 
-			 ON CONFLICT (slug) DO UPDATE SET name = EXCLUDED.name, district_id = EXCLUDED.district_id`,
-			districtID, constituency.Name, constituency.Slug,
-		)
-
-		if err != nil {
-			return fmt.Errorf("failed to insert constituency %s: %w", constituency.Slug, err)
+		from, to, ok := validity(constituency.Slug, constituency.Validity)
+		if !ok {
+			continue
 		}
+		s.rows = append(s.rows, []any{constituency.Slug, regionID, districtID, constituency.Name, constituency.ECNumber, from, to})
 	}
-
-	return nil
+	return s
 }
 
-func seedCities(ctx context.Context, pool *pgxpool.Pool, districtMap map[string]string) error {
-	data, err := os.ReadFile("data/cities.json")
+// validity returns the valid_from and valid_to column values of a record,
+// warning and reporting false when its dates are invalid.
+func validity(slug string, v dataset.Validity) (from, to *time.Time, ok bool) {
+	from, to, err := v.Dates()
 	if err != nil {
-		return fmt.Errorf("failed to read cities.json: %w", err)
+		fmt.Printf("  ⚠ Invalid validity period for %s: %v\n", slug, err)
+		return nil, nil, false
 	}
+	return from, to, true
+}
 
-	var cities []CityData
-	if err := json.Unmarshal(data, &cities); err != nil {
-		return fmt.Errorf("failed to parse cities.json: %w", err)
-	}
+// citiesSync keeps the slugs of the cities already in the table, given by
+// CityKey in existing, so their URLs survive namesakes added to the data.
+func citiesSync(ds *dataset.Dataset, districtMap, existing map[string]string) tableSync {
+	s := tableSync{table: "cities", keys: []string{"district_id", "name"}, columns: []string{"slug", "lat", "lng"}}
+	slugs := ds.CitySlugs(existing)
+	for _, city := range ds.Cities {
+		if city.Name == "" {
+			// Log warning but continue
+			fmt.Printf("  ⚠ City without a name in %s\n", city.DistrictSlug)
+			continue
+		}
 
-	for _, city := range cities {
 		districtID, exists := districtMap[city.DistrictSlug]
 		if !exists {
 			// Log warning but continue
 			fmt.Printf("  ⚠ District not found for city %s: %s\n", city.Name, city.DistrictSlug)
 			continue
 		}
+		s.rows = append(s.rows, []any{districtID, city.Name, slugs[dataset.CityKey(city.DistrictSlug, city.Name)], city.Lat, city.Lng})
+	}
+	return s
+}
 
-		_, err := pool.Exec(ctx,
-			`INSERT INTO cities (district_id, name, lat, lng) 
-			 VALUES ($1, $2, $3, $4) 
-			 ON CONFLICT (district_id, name) DO UPDATE SET lat = EXCLUDED.lat, lng = EXCLUDED.lng`,
-			districtID, city.Name, city.Lat, city.Lng,
-		)
-
+func lineageSync(links []dataset.LineageLink) tableSync {
+	s := tableSync{table: "lineage", keys: []string{"kind", "predecessor_slug", "successor_slug"}, columns: []string{"type", "effective_date"}}
+	for _, link := range links {
+		date, err := time.Parse(time.DateOnly, link.Date)
 		if err != nil {
-			return fmt.Errorf("failed to insert city %s: %w", city.Name, err)
+			// Log warning but continue
+			fmt.Printf("  ⚠ Invalid date for lineage of %s %s: %s\n", link.Kind, link.Predecessor, link.Date)
+			continue
 		}
+		s.rows = append(s.rows, []any{link.Kind, link.Predecessor, link.Successor, link.Type, date})
+	}
+	return s
+}
+
+func alternateNamesSync(names []dataset.AlternateName) tableSync {
+	s := tableSync{table: "alternate_names", keys: []string{"kind", "name_key"}, columns: []string{"slug", "name", "type"}}
+	for _, name := range names {
+		s.rows = append(s.rows, []any{name.Kind, dataset.Slugify(name.Name), name.Slug, name.Name, name.Type})
 	}
+	return s
+}
 
-	return nil
+func localizedNamesSync(names []dataset.LocalizedName) tableSync {
+	s := tableSync{table: "localized_names", keys: []string{"kind", "slug", "lang"}, columns: []string{"name"}}
+	for _, name := range names {
+		s.rows = append(s.rows, []any{name.Kind, name.Slug, name.Lang, name.Name})
+	}
+	return s
 }
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ghana-location-api/pkg/dataset"
+)
+
+func pollingStationsSync(stations []dataset.PollingStation, regionMap, districtMap, constituencyMap map[string]string) tableSync {
+	s := tableSync{
+		table:   "polling_stations",
+		keys:    []string{"code"},
+		columns: []string{"region_id", "district_id", "constituency_id", "name"},
+	}
+
+	unresolved := 0
+	for _, station := range stations {
+		regionID, exists := regionMap[station.RegionSlug]
+		if !exists {
+			fmt.Printf("  ⚠ Region not found for polling station %s: %s\n", station.Code, station.RegionSlug)
+			continue
+		}
+
+		var districtID, constituencyID any
+		if station.DistrictSlug != nil {
+			if id, exists := districtMap[*station.DistrictSlug]; exists {
+				districtID = id
+			}
+		}
+		if station.ConstituencySlug != nil {
+			if id, exists := constituencyMap[*station.ConstituencySlug]; exists {
+				constituencyID = id
+			}
+		}
+		if constituencyID == nil {
+			unresolved++
+		}
+
+		s.rows = append(s.rows, []any{station.Code, regionID, districtID, constituencyID, station.Name})
+	}
+
+	fmt.Printf("  %d polling stations parsed, %d without a known constituency\n", len(stations), unresolved)
+	return s
+}
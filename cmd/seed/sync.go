@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ghana-location-api/pkg/dataset"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// tableSync describes the desired contents of a table. Rows are matched to
+// existing rows on the key columns; the remaining columns are updated.
+type tableSync struct {
+	table   string
+	keys    []string
+	columns []string
+	rows    [][]any // key values followed by column values
+
+	// retire keeps rows that are not in the dataset, ending their validity
+	// period today instead of deleting them, so that historical queries
+	// still find them. Rows that only start today or later end the day after
+	// they start, as the period may not be empty. The table must have
+	// valid_from and valid_to columns.
+	retire bool
+}
+
+type syncDiff struct {
+	Inserted  int64
+	Updated   int64
+	Unchanged int64
+	Deleted   int64
+	Retired   int64
+}
+
+// syncTable makes table hold exactly the given rows: rows missing from the
+// table are inserted, rows whose columns differ are updated and rows not in
+// the dataset are deleted, or retired when s.retire is set. The rows are copied into a temporary table with
+// COPY so the changes are applied with three set-based statements.
+func syncTable(ctx context.Context, tx pgx.Tx, s tableSync) (syncDiff, error) {
+	var diff syncDiff
+	all := append(append([]string{}, s.keys...), s.columns...)
+	staging := "seed_" + s.table
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(
+		"CREATE TEMP TABLE %s ON COMMIT DROP AS SELECT %s FROM %s WITH NO DATA",
+		staging, strings.Join(all, ", "), s.table,
+	)); err != nil {
+		return diff, fmt.Errorf("failed to create staging table: %w", err)
+	}
+
+	rows := dedupe(s.rows, len(s.keys))
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{staging}, all, pgx.CopyFromRows(rows)); err != nil {
+		return diff, fmt.Errorf("failed to copy rows: %w", err)
+	}
+
+	match := make([]string, len(s.keys))
+	for i, key := range s.keys {
+		match[i] = fmt.Sprintf("t.%[1]s = s.%[1]s", key)
+	}
+	on := strings.Join(match, " AND ")
+
+	var tag pgconn.CommandTag
+	var err error
+	if s.retire {
+		tag, err = tx.Exec(ctx, fmt.Sprintf(
+			"UPDATE %s t SET valid_to = GREATEST(CURRENT_DATE, t.valid_from + 1) WHERE t.valid_to IS NULL AND NOT EXISTS (SELECT 1 FROM %s s WHERE %s)",
+			s.table, staging, on,
+		))
+		if err != nil {
+			return diff, fmt.Errorf("failed to retire rows: %w", err)
+		}
+		diff.Retired = tag.RowsAffected()
+	} else {
+		tag, err = tx.Exec(ctx, fmt.Sprintf(
+			"DELETE FROM %s t WHERE NOT EXISTS (SELECT 1 FROM %s s WHERE %s)",
+			s.table, staging, on,
+		))
+		if err != nil {
+			return diff, fmt.Errorf("failed to delete rows: %w", err)
+		}
+		diff.Deleted = tag.RowsAffected()
+	}
+
+	if len(s.columns) > 0 {
+		set := make([]string, len(s.columns))
+		target := make([]string, len(s.columns))
+		source := make([]string, len(s.columns))
+		for i, column := range s.columns {
+			set[i] = fmt.Sprintf("%[1]s = s.%[1]s", column)
+			target[i] = "t." + column
+			source[i] = "s." + column
+		}
+		tag, err = tx.Exec(ctx, fmt.Sprintf(
+			"UPDATE %s t SET %s FROM %s s WHERE %s AND (%s) IS DISTINCT FROM (%s)",
+			s.table, strings.Join(set, ", "), staging, on, strings.Join(target, ", "), strings.Join(source, ", "),
+		))
+		if err != nil {
+			return diff, fmt.Errorf("failed to update rows: %w", err)
+		}
+		diff.Updated = tag.RowsAffected()
+	}
+
+	tag, err = tx.Exec(ctx, fmt.Sprintf(
+		"INSERT INTO %[1]s (%[2]s) SELECT %[2]s FROM %[3]s s WHERE NOT EXISTS (SELECT 1 FROM %[1]s t WHERE %[4]s)",
+		s.table, strings.Join(all, ", "), staging, on,
+	))
+	if err != nil {
+		return diff, fmt.Errorf("failed to insert rows: %w", err)
+	}
+	diff.Inserted = tag.RowsAffected()
+	diff.Unchanged = int64(len(rows)) - diff.Inserted - diff.Updated
+
+	return diff, nil
+}
+
+// dedupe keeps the last row for each key, as later entries in the data files
+// replace earlier ones.
+func dedupe(rows [][]any, keys int) [][]any {
+	index := make(map[string]int, len(rows))
+	var unique [][]any
+	for _, row := range rows {
+		key := fmt.Sprintf("%q", row[:keys])
+		if i, ok := index[key]; ok {
+			unique[i] = row
+			continue
+		}
+		index[key] = len(unique)
+		unique = append(unique, row)
+	}
+	return unique
+}
+
+// idsBySlug maps the slugs of a table to their ids.
+func idsBySlug(ctx context.Context, tx pgx.Tx, table string) (map[string]string, error) {
+	rows, err := tx.Query(ctx, fmt.Sprintf("SELECT slug, id FROM %s", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]string)
+	for rows.Next() {
+		var slug, id string
+		if err := rows.Scan(&slug, &id); err != nil {
+			return nil, err
+		}
+		ids[slug] = id
+	}
+	return ids, rows.Err()
+}
+
+// citySlugs returns the slugs of the cities in the database, keyed by
+// dataset.CityKey.
+func citySlugs(ctx context.Context, tx pgx.Tx) (map[string]string, error) {
+	rows, err := tx.Query(ctx, "SELECT d.slug, c.name, c.slug FROM cities c JOIN districts d ON c.district_id = d.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	slugs := make(map[string]string)
+	for rows.Next() {
+		var districtSlug, name, slug string
+		if err := rows.Scan(&districtSlug, &name, &slug); err != nil {
+			return nil, err
+		}
+		slugs[dataset.CityKey(districtSlug, name)] = slug
+	}
+	return slugs, rows.Err()
+}
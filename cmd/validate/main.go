@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ghana-location-api/pkg/dataset"
+)
+
+func main() {
+	dir := flag.String("dir", "data", "directory holding the data files")
+	flag.Parse()
+
+	dataFS := os.DirFS(*dir)
+	ds, err := dataset.Load(dataFS)
+	if err != nil {
+		log.Fatalf("failed to load data: %v", err)
+	}
+
+	fmt.Printf("Validating %s...\n", *dir)
+	fmt.Printf("  %d countries, %d regions, %d districts, %d constituencies, %d cities\n",
+		len(ds.Countries), len(ds.Regions), len(ds.Districts), len(ds.Constituencies), len(ds.Cities))
+
+	issues := ds.Validate()
+
+	stations, err := dataset.LoadPollingStations(dataFS, ds)
+	if err != nil {
+		log.Fatalf("failed to load polling stations: %v", err)
+	}
+	fmt.Printf("  %d polling stations\n", len(stations))
+	codes := make(map[string]bool, len(stations))
+	for _, station := range stations {
+		if codes[station.Code] {
+			issues = append(issues, dataset.Issue{File: dataset.PollingStationsFile, Record: station.Code, Message: "duplicate code"})
+		}
+		codes[station.Code] = true
+		if station.SharedPrefix != nil {
+			issues = append(issues, dataset.Issue{
+				File:    dataset.PollingStationsFile,
+				Record:  station.Code,
+				Message: fmt.Sprintf("constituency not recognized, and the code prefix is shared by %s", strings.Join(station.SharedPrefix, ", ")),
+				Warning: true,
+			})
+		}
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("\n✓ No problems found")
+		return
+	}
+
+	// Warnings are listed but only problems fail the run.
+	fmt.Println()
+	problems := 0
+	for _, issue := range issues {
+		if issue.Warning {
+			fmt.Printf("⚠ %s\n", issue)
+			continue
+		}
+		fmt.Printf("✗ %s\n", issue)
+		problems++
+	}
+	if problems == 0 {
+		fmt.Printf("\n✓ No problems found, %d warning(s)\n", len(issues))
+		return
+	}
+	fmt.Printf("\n✗ %d problem(s) found, %d warning(s)\n", problems, len(issues)-problems)
+	os.Exit(1)
+}
@@ -42,4 +42,7 @@ func main() {
 
 	pool.QueryRow(ctx, "SELECT COUNT(*) FROM cities").Scan(&count)
 	fmt.Printf("Cities: %d\n", count)
+
+	pool.QueryRow(ctx, "SELECT COUNT(*) FROM polling_stations").Scan(&count)
+	fmt.Printf("Polling stations: %d\n", count)
 }
@@ -0,0 +1,8 @@
+// Package data embeds the normalized dataset files so the API can run
+// without a database.
+package data
+
+import "embed"
+
+//go:embed *.json polling_station.txt
+var FS embed.FS
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS cities;
+DROP TABLE IF EXISTS constituencies;
+DROP TABLE IF EXISTS districts;
+DROP TABLE IF EXISTS regions;
+DROP TABLE IF EXISTS countries;
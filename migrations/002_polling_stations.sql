@@ -0,0 +1,15 @@
+-- Polling stations table
+CREATE TABLE polling_stations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    region_id UUID NOT NULL REFERENCES regions(id) ON DELETE CASCADE,
+    district_id UUID REFERENCES districts(id) ON DELETE SET NULL,
+    constituency_id UUID REFERENCES constituencies(id) ON DELETE SET NULL,
+    code VARCHAR(16) UNIQUE NOT NULL,
+    name VARCHAR NOT NULL
+);
+
+-- Indexes for performance
+CREATE INDEX idx_polling_stations_region_id ON polling_stations(region_id);
+CREATE INDEX idx_polling_stations_district_id ON polling_stations(district_id);
+CREATE INDEX idx_polling_stations_constituency_id ON polling_stations(constituency_id);
+CREATE INDEX idx_polling_stations_code ON polling_stations(code);
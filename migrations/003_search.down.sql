@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_cities_name_search;
+DROP INDEX IF EXISTS idx_constituencies_name_search;
+DROP INDEX IF EXISTS idx_districts_name_search;
+DROP INDEX IF EXISTS idx_regions_name_search;
+
+DROP FUNCTION IF EXISTS search_normalize(TEXT);
+
+-- The pg_trgm and unaccent extensions are left installed, as other
+-- schemas in the database may use them.
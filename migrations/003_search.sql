@@ -0,0 +1,14 @@
+-- Enable trigram and accent-folding extensions for search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE, so wrap it to allow expression indexes
+CREATE OR REPLACE FUNCTION search_normalize(value TEXT) RETURNS TEXT AS $$
+    SELECT lower(public.unaccent('public.unaccent', value))
+$$ LANGUAGE SQL IMMUTABLE PARALLEL SAFE STRICT;
+
+-- Trigram indexes on normalized names
+CREATE INDEX idx_regions_name_search ON regions USING GIN (search_normalize(name) gin_trgm_ops);
+CREATE INDEX idx_districts_name_search ON districts USING GIN (search_normalize(name) gin_trgm_ops);
+CREATE INDEX idx_constituencies_name_search ON constituencies USING GIN (search_normalize(name) gin_trgm_ops);
+CREATE INDEX idx_cities_name_search ON cities USING GIN (search_normalize(name) gin_trgm_ops);
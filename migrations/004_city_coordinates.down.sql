@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_cities_lat_lng;
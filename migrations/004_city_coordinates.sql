@@ -0,0 +1,2 @@
+-- Index for bounding-box lookups on city coordinates
+CREATE INDEX idx_cities_lat_lng ON cities(lat, lng) WHERE lat IS NOT NULL AND lng IS NOT NULL;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_districts_boundary;
+DROP INDEX IF EXISTS idx_regions_boundary;
+
+ALTER TABLE districts DROP COLUMN IF EXISTS boundary;
+ALTER TABLE regions DROP COLUMN IF EXISTS boundary;
+
+-- The postgis extension is left installed, as other schemas in the
+-- database may use it.
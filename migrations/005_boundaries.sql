@@ -0,0 +1,10 @@
+-- Enable PostGIS for administrative boundaries
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+-- Boundary polygons (WGS 84), imported with cmd/boundaries
+ALTER TABLE regions ADD COLUMN boundary geometry(MultiPolygon, 4326);
+ALTER TABLE districts ADD COLUMN boundary geometry(MultiPolygon, 4326);
+
+-- Spatial indexes for point-in-polygon lookups
+CREATE INDEX idx_regions_boundary ON regions USING GIST (boundary);
+CREATE INDEX idx_districts_boundary ON districts USING GIST (boundary);
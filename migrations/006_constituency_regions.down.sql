@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_constituencies_region_id;
+
+ALTER TABLE constituencies DROP COLUMN IF EXISTS region_id;
@@ -0,0 +1,11 @@
+-- Region of each constituency, seeded from region-constituencies.json so
+-- constituencies without a known district still belong to a region
+ALTER TABLE constituencies ADD COLUMN region_id UUID REFERENCES regions(id) ON DELETE CASCADE;
+
+-- Backfill from the district until the next seed
+UPDATE constituencies c
+SET region_id = d.region_id
+FROM districts d
+WHERE c.district_id = d.id;
+
+CREATE INDEX idx_constituencies_region_id ON constituencies(region_id);
@@ -0,0 +1,3 @@
+ALTER TABLE cities DROP CONSTRAINT IF EXISTS cities_slug_key;
+
+ALTER TABLE cities DROP COLUMN IF EXISTS slug;
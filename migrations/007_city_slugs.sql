@@ -0,0 +1,17 @@
+-- Public identifier for cities
+ALTER TABLE cities ADD COLUMN slug VARCHAR;
+
+-- Provisional slugs for existing rows, unique through the id suffix;
+-- cmd/seed replaces them with the slugs derived from cities.json
+UPDATE cities c
+SET slug = concat_ws('-',
+    NULLIF(trim(BOTH '-' FROM regexp_replace(lower(c.name), '[^a-z0-9]+', '-', 'g')), ''),
+    regexp_replace(d.slug, '-(district|municipal|metro)$', ''),
+    left(c.id::text, 8))
+FROM districts d
+WHERE c.district_id = d.id;
+
+ALTER TABLE cities ALTER COLUMN slug SET NOT NULL;
+
+-- Deferred so the seeder can reassign slugs between cities in one transaction
+ALTER TABLE cities ADD CONSTRAINT cities_slug_key UNIQUE (slug) DEFERRABLE INITIALLY DEFERRED;
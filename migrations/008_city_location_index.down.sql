@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_cities_location;
+
+CREATE INDEX idx_cities_lat_lng ON cities(lat, lng) WHERE lat IS NOT NULL AND lng IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_cities_lat_lng;
+
+-- Spatial index on city coordinates for bounding-box lookups; a B-tree on
+-- (lat, lng) can only narrow the search by latitude
+CREATE INDEX idx_cities_location ON cities USING GIST (point(lng::float8, lat::float8))
+WHERE lat IS NOT NULL AND lng IS NOT NULL;
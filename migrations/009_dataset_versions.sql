@@ -0,0 +1,7 @@
+-- Each seed records the version of the data files it loaded; the latest row
+-- is the version the API serves
+CREATE TABLE dataset_versions (
+    id SERIAL PRIMARY KEY,
+    version VARCHAR(64) NOT NULL,
+    seeded_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,5 @@
+ALTER TABLE constituencies DROP CONSTRAINT IF EXISTS constituencies_region_id_ec_number_key;
+ALTER TABLE constituencies DROP COLUMN IF EXISTS ec_number;
+
+ALTER TABLE regions DROP CONSTRAINT IF EXISTS regions_ec_letter_key;
+ALTER TABLE regions DROP COLUMN IF EXISTS ec_letter;
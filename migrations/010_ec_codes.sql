@@ -0,0 +1,8 @@
+-- Electoral Commission polling station codes start with a region letter and
+-- a constituency number within the region; both are seeded from the data
+-- files so codes can be decoded
+ALTER TABLE regions ADD COLUMN ec_letter CHAR(1);
+ALTER TABLE regions ADD CONSTRAINT regions_ec_letter_key UNIQUE (ec_letter) DEFERRABLE INITIALLY DEFERRED;
+
+ALTER TABLE constituencies ADD COLUMN ec_number SMALLINT;
+ALTER TABLE constituencies ADD CONSTRAINT constituencies_region_id_ec_number_key UNIQUE (region_id, ec_number) DEFERRABLE INITIALLY DEFERRED;
@@ -0,0 +1,8 @@
+ALTER TABLE constituencies DROP CONSTRAINT IF EXISTS constituencies_validity_check;
+ALTER TABLE constituencies DROP COLUMN IF EXISTS valid_from, DROP COLUMN IF EXISTS valid_to;
+
+ALTER TABLE districts DROP CONSTRAINT IF EXISTS districts_validity_check;
+ALTER TABLE districts DROP COLUMN IF EXISTS valid_from, DROP COLUMN IF EXISTS valid_to;
+
+ALTER TABLE regions DROP CONSTRAINT IF EXISTS regions_validity_check;
+ALTER TABLE regions DROP COLUMN IF EXISTS valid_from, DROP COLUMN IF EXISTS valid_to;
@@ -0,0 +1,13 @@
+-- Regions, districts and constituencies exist for a period: from valid_from
+-- up to, but not including, valid_to. A NULL valid_from means the record
+-- existed before records begin and a NULL valid_to that it still exists. The
+-- seeder retires records that leave the data files instead of deleting them,
+-- so queries with as_of still find them
+ALTER TABLE regions ADD COLUMN valid_from DATE, ADD COLUMN valid_to DATE;
+ALTER TABLE regions ADD CONSTRAINT regions_validity_check CHECK (valid_from < valid_to);
+
+ALTER TABLE districts ADD COLUMN valid_from DATE, ADD COLUMN valid_to DATE;
+ALTER TABLE districts ADD CONSTRAINT districts_validity_check CHECK (valid_from < valid_to);
+
+ALTER TABLE constituencies ADD COLUMN valid_from DATE, ADD COLUMN valid_to DATE;
+ALTER TABLE constituencies ADD CONSTRAINT constituencies_validity_check CHECK (valid_from < valid_to);
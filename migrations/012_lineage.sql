@@ -0,0 +1,15 @@
+-- Lineage records how districts and constituencies were split, merged and
+-- renamed: each row links a record to one of the records an event turned it
+-- into. Records are referenced by slug, as the seeder retires rather than
+-- deletes them and their slugs never change
+CREATE TABLE lineage (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR NOT NULL CHECK (kind IN ('district', 'constituency')),
+    predecessor_slug VARCHAR NOT NULL,
+    successor_slug VARCHAR NOT NULL,
+    type VARCHAR NOT NULL CHECK (type IN ('split', 'merge', 'rename')),
+    effective_date DATE NOT NULL,
+    UNIQUE (kind, predecessor_slug, successor_slug)
+);
+
+CREATE INDEX idx_lineage_successor ON lineage(kind, successor_slug);
@@ -0,0 +1,12 @@
+-- Alternate names are the other names a region, district, constituency or
+-- city is known by. Lookups by a slug that matches no record fall back to
+-- name_key, the name slugified, and redirect to the record's slug
+CREATE TABLE alternate_names (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR NOT NULL CHECK (kind IN ('region', 'district', 'constituency', 'city')),
+    name_key VARCHAR NOT NULL,
+    slug VARCHAR NOT NULL,
+    name VARCHAR NOT NULL,
+    type VARCHAR NOT NULL CHECK (type IN ('historical', 'variant', 'abbreviation')),
+    UNIQUE (kind, name_key)
+);
@@ -0,0 +1,13 @@
+-- Localized names are the names of regions, districts, constituencies and
+-- cities in languages other than English, by ISO 639 code. Responses show
+-- the name in the requested language, or the English name where it has none
+CREATE TABLE localized_names (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR NOT NULL CHECK (kind IN ('region', 'district', 'constituency', 'city')),
+    slug VARCHAR NOT NULL,
+    lang VARCHAR NOT NULL CHECK (lang ~ '^[a-z]{2,3}$' AND lang <> 'en'),
+    name VARCHAR NOT NULL,
+    UNIQUE (kind, slug, lang)
+);
+
+CREATE INDEX idx_localized_names_lang ON localized_names(lang);
@@ -0,0 +1,15 @@
+-- District regions record which region a district belonged to over time, as
+-- districts moved to the regions created in 2019. The periods of a district
+-- follow each other and together span its validity; districts.region_id is
+-- its current region, used for districts without rows here
+CREATE TABLE district_regions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    district_id UUID NOT NULL REFERENCES districts(id) ON DELETE CASCADE,
+    region_id UUID NOT NULL REFERENCES regions(id) ON DELETE CASCADE,
+    valid_from DATE,
+    valid_to DATE,
+    UNIQUE (district_id, region_id),
+    CHECK (valid_from < valid_to)
+);
+
+CREATE INDEX idx_district_regions_region ON district_regions(region_id);
@@ -0,0 +1 @@
+ALTER TABLE dataset_versions DROP COLUMN IF EXISTS tables;
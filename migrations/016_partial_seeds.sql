@@ -0,0 +1,3 @@
+-- A seed of only some tables records which ones it loaded, as the others
+-- still hold the data of an earlier seed; tables is NULL for a full seed
+ALTER TABLE dataset_versions ADD COLUMN tables TEXT[];
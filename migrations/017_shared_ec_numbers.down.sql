@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_constituencies_ec_number;
+ALTER TABLE constituencies ADD CONSTRAINT constituencies_region_id_ec_number_key UNIQUE (region_id, ec_number) DEFERRABLE INITIALLY DEFERRED;
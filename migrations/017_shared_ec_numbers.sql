@@ -0,0 +1,4 @@
+-- Constituencies split from one another keep the EC number of the
+-- constituency they came from, so numbers are not unique within a region
+ALTER TABLE constituencies DROP CONSTRAINT constituencies_region_id_ec_number_key;
+CREATE INDEX idx_constituencies_ec_number ON constituencies(region_id, ec_number);
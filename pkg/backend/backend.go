@@ -0,0 +1,115 @@
+// Package backend builds the LocationService for the configured data source.
+package backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ghana-location-api/data"
+	"github.com/ghana-location-api/pkg/cache"
+	"github.com/ghana-location-api/pkg/config"
+	"github.com/ghana-location-api/pkg/repositories"
+	"github.com/ghana-location-api/pkg/repositories/cached"
+	"github.com/ghana-location-api/pkg/repositories/memory"
+	"github.com/ghana-location-api/pkg/services"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Backend is the LocationService for a data source and the resources behind it.
+type Backend struct {
+	Service *services.LocationService
+	// Pool is the PostgreSQL connection pool, or nil when the embedded
+	// dataset is served.
+	Pool *pgxpool.Pool
+	// Cache is the query cache in front of PostgreSQL, or nil when the
+	// embedded dataset is served or caching is off.
+	Cache *cache.Cache
+
+	close func()
+}
+
+// New connects to the data source selected by cfg.DataSource.
+func New(ctx context.Context, cfg *config.Config) (*Backend, error) {
+	switch cfg.DataSource {
+	case config.DataSourceEmbedded:
+		return newEmbedded()
+	case config.DataSourcePostgres:
+		return newPostgres(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("unknown data source: %s", cfg.DataSource)
+	}
+}
+
+// Close releases the resources of the backend.
+func (b *Backend) Close() {
+	b.close()
+}
+
+func newPostgres(ctx context.Context, cfg *config.Config) (*Backend, error) {
+	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database pool: %w", err)
+	}
+
+	// Test connection with timeout
+	pingCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := pool.Ping(pingCtx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	repos := cached.Repositories{
+		Country:        repositories.NewCountryRepository(pool),
+		Region:         repositories.NewRegionRepository(pool),
+		District:       repositories.NewDistrictRepository(pool),
+		Constituency:   repositories.NewConstituencyRepository(pool),
+		City:           repositories.NewCityRepository(pool),
+		PollingStation: repositories.NewPollingStationRepository(pool),
+		Search:         repositories.NewSearchRepository(pool),
+		Lineage:        repositories.NewLineageRepository(pool),
+		AlternateName:  repositories.NewAlternateNameRepository(pool),
+		LocalizedName:  repositories.NewLocalizedNameRepository(pool),
+		Meta:           repositories.NewMetaRepository(pool),
+	}
+
+	b := &Backend{Pool: pool, close: pool.Close}
+	switch cfg.Cache {
+	case config.CacheLRU:
+		b.Service, b.Cache = cached.NewLocationService(repos, cache.NewLRU(cfg.CacheSize))
+	case config.CacheRedis:
+		store, err := cache.NewRedis(cfg.RedisURL, cfg.CacheTTL)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+		b.Service, b.Cache = cached.NewLocationService(repos, store)
+	default:
+		b.Service = services.NewLocationService(
+			repos.Country,
+			repos.Region,
+			repos.District,
+			repos.Constituency,
+			repos.City,
+			repos.PollingStation,
+			repos.Search,
+			repos.Lineage,
+			repos.AlternateName,
+			repos.LocalizedName,
+			repos.Meta,
+		)
+	}
+	return b, nil
+}
+
+// newEmbedded serves the dataset compiled into the binary. It is already
+// held in memory, so it is not cached.
+func newEmbedded() (*Backend, error) {
+	store, err := memory.NewStore(data.FS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded dataset: %w", err)
+	}
+
+	return &Backend{Service: memory.NewLocationService(store), close: func() {}}, nil
+}
@@ -0,0 +1,150 @@
+// Package cache keeps query results for the cached repositories in a
+// pluggable store: an in-process LRU or Redis.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+// Store holds encoded query results by key. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+}
+
+// purger is implemented by stores that can drop every entry at once, which
+// the Cache does when the dataset version changes.
+type purger interface {
+	Purge()
+}
+
+// Stats counts the lookups of one kind of query. Errors are store failures,
+// which are also counted as misses.
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+	Errors uint64
+}
+
+type counters struct {
+	hits, misses, errors atomic.Uint64
+}
+
+// Cache reads query results through a Store. Keys include the dataset
+// version, so results cached for an older version are never served once the
+// data is reseeded.
+type Cache struct {
+	store   Store
+	version func(ctx context.Context) (string, error)
+
+	mu          sync.Mutex
+	stats       map[string]*counters
+	lastVersion string
+}
+
+// New returns a Cache over store. version returns the dataset version being
+// served, or "" when none is known, in which case nothing is cached. It must
+// change with every seed, as models.DatasetVersion.Revision does, including
+// seeds of some tables only.
+func New(store Store, version func(ctx context.Context) (string, error)) *Cache {
+	return &Cache{store: store, version: version, stats: make(map[string]*counters)}
+}
+
+// Stats returns the lookup counts of each kind of query.
+func (c *Cache) Stats() map[string]Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make(map[string]Stats, len(c.stats))
+	for kind, counters := range c.stats {
+		stats[kind] = Stats{Hits: counters.hits.Load(), Misses: counters.misses.Load(), Errors: counters.errors.Load()}
+	}
+	return stats
+}
+
+func (c *Cache) counters(kind string) *counters {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if counters, ok := c.stats[kind]; ok {
+		return counters
+	}
+	counters := &counters{}
+	c.stats[kind] = counters
+	return counters
+}
+
+// key returns the store key of a query under the current dataset version,
+// or "" when results should not be cached.
+func (c *Cache) key(ctx context.Context, kind string, args []any) string {
+	version, err := c.version(ctx)
+	if err != nil || version == "" {
+		return ""
+	}
+
+	c.mu.Lock()
+	if version != c.lastVersion {
+		if p, ok := c.store.(purger); ok && c.lastVersion != "" {
+			p.Purge()
+		}
+		c.lastVersion = version
+	}
+	c.mu.Unlock()
+
+	// Results depend on the date the hierarchy is queried on, and lookups
+	// by slug also on whether one was asked for.
+	scope := "current:" + models.Today().String()
+	if date, ok := models.AsOf(ctx); ok {
+		scope = "as_of:" + date.String()
+	}
+	encoded, err := json.Marshal(append([]any{scope}, args...))
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(encoded)
+	return "ghana-location-api:" + version + ":" + kind + ":" + hex.EncodeToString(sum[:16])
+}
+
+// Get returns the result of the kind of query with the given arguments from
+// the cache, or calls load and caches its result. A failing store is
+// bypassed, so an unreachable Redis slows requests down but does not fail
+// them.
+func Get[T any](ctx context.Context, c *Cache, kind string, args []any, load func() (T, error)) (T, error) {
+	key := c.key(ctx, kind, args)
+	if key == "" {
+		return load()
+	}
+
+	counters := c.counters(kind)
+	data, found, err := c.store.Get(ctx, key)
+	if err != nil {
+		counters.errors.Add(1)
+	}
+	if found {
+		var result T
+		if err := json.Unmarshal(data, &result); err == nil {
+			counters.hits.Add(1)
+			return result, nil
+		}
+	}
+	counters.misses.Add(1)
+
+	result, err := load()
+	if err != nil {
+		return result, err
+	}
+	if data, err := json.Marshal(result); err == nil {
+		if err := c.store.Set(ctx, key, data); err != nil {
+			counters.errors.Add(1)
+		}
+	}
+	return result, nil
+}
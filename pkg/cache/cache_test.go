@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+func TestGetReloadsAfterSeed(t *testing.T) {
+	ctx := context.Background()
+	version := models.DatasetVersion{Seed: 1, Version: "fe8057d398dade93"}
+	store := NewLRU(10)
+	c := New(store, func(context.Context) (string, error) {
+		return version.Revision(), nil
+	})
+
+	loads := 0
+	get := func() int {
+		n, err := Get(ctx, c, "test", []any{"arg"}, func() (int, error) {
+			loads++
+			return loads, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	if got := get(); got != 1 {
+		t.Fatalf("first Get = %d, want 1", got)
+	}
+	if got := get(); got != 1 {
+		t.Errorf("cached Get = %d, want 1", got)
+	}
+
+	// A seed of some tables leaves the version of the data files as it was
+	// but records a new seed.
+	version.Seed = 2
+	if got := get(); got != 2 {
+		t.Errorf("Get after a new seed = %d, want 2", got)
+	}
+	if store.Len() != 1 {
+		t.Errorf("LRU holds %d entries after a new seed, want 1", store.Len())
+	}
+}
+
+func TestGetWithoutVersion(t *testing.T) {
+	c := New(NewLRU(10), func(context.Context) (string, error) {
+		return "", nil
+	})
+	loads := 0
+	for range 2 {
+		Get(context.Background(), c, "test", nil, func() (int, error) {
+			loads++
+			return loads, nil
+		})
+	}
+	if loads != 2 {
+		t.Errorf("loaded %d times without a version, want 2", loads)
+	}
+}
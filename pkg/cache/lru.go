@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// LRU is an in-process Store holding up to a fixed number of entries,
+// evicting the least recently used.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is most recently used
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+// NewLRU returns an LRU holding up to capacity entries.
+func NewLRU(capacity int) *LRU {
+	return &LRU{capacity: capacity, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (c *LRU) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry).value, true, nil
+}
+
+func (c *LRU) Set(ctx context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruEntry).value = value
+		c.order.MoveToFront(element)
+		return nil
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// Purge drops every entry.
+func (c *LRU) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.entries)
+}
+
+// Len returns the number of entries held.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,196 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	redisDialTimeout = 2 * time.Second
+	redisOpTimeout   = time.Second
+	redisMaxIdle     = 8
+)
+
+// Redis is a Store backed by a Redis server, shared by every API instance
+// that points at it. Entries expire after a fixed TTL, which also clears out
+// the entries of older dataset versions. It speaks just enough of the RESP
+// protocol for GET and SET.
+type Redis struct {
+	addr     string
+	username string
+	password string
+	db       int
+	tls      *tls.Config // nil for plain TCP
+	ttl      time.Duration
+	idle     chan *redisConn
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedis returns a Redis store for a URL of the form
+// redis://[[user]:password@]host[:port][/db], or rediss:// for a server
+// that requires TLS. Connections are opened on first use.
+func NewRedis(rawURL string, ttl time.Duration) (*Redis, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid Redis URL: %q", rawURL)
+	}
+
+	r := &Redis{addr: u.Host, ttl: ttl, idle: make(chan *redisConn, redisMaxIdle)}
+	if u.Scheme == "rediss" {
+		r.tls = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	}
+	if u.Port() == "" {
+		r.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		r.username = u.User.Username()
+		r.password, _ = u.User.Password()
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if r.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("invalid Redis database: %q", db)
+		}
+	}
+	return r, nil
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	return reply, true, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte) error {
+	_, err := r.do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(r.ttl.Milliseconds(), 10))
+	return err
+}
+
+// do runs one command on a pooled connection. A connection that fails is
+// closed rather than returned to the pool.
+func (r *Redis) do(ctx context.Context, args ...string) ([]byte, error) {
+	c, err := r.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > redisOpTimeout {
+		deadline = time.Now().Add(redisOpTimeout)
+	}
+	c.conn.SetDeadline(deadline)
+
+	reply, err := c.command(args...)
+	if err != nil {
+		c.conn.Close()
+		return nil, err
+	}
+
+	select {
+	case r.idle <- c:
+	default:
+		c.conn.Close()
+	}
+	return reply, nil
+}
+
+func (r *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-r.idle:
+		return c, nil
+	default:
+	}
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: redisDialTimeout}
+	if r.tls != nil {
+		tlsDialer := tls.Dialer{NetDialer: dialer, Config: r.tls}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", r.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", r.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+	conn.SetDeadline(time.Now().Add(redisOpTimeout))
+
+	if r.password != "" {
+		auth := []string{"AUTH", r.password}
+		if r.username != "" {
+			auth = []string{"AUTH", r.username, r.password}
+		}
+		if _, err := c.command(auth...); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if r.db != 0 {
+		if _, err := c.command("SELECT", strconv.Itoa(r.db)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// command sends args as a RESP array and reads the reply. Bulk strings are
+// returned as is, a null bulk string as nil and other replies as their text.
+func (c *redisConn) command(args ...string) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := c.conn.Write([]byte(b.String())); err != nil {
+		return nil, err
+	}
+
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("empty Redis reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid Redis reply: %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.reader, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	default:
+		return nil, fmt.Errorf("unexpected Redis reply: %q", line)
+	}
+}
@@ -0,0 +1,197 @@
+// Package client is a Go client for the Ghana Location API.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 3
+	defaultRetryWait  = 200 * time.Millisecond
+	maxRetryWait      = 5 * time.Second
+)
+
+// Client calls the Ghana Location API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	maxRetries int
+	retryWait  time.Duration
+	userAgent  string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how many times a failed request is retried and the wait
+// before the first retry. The wait doubles on each further retry.
+func WithRetries(maxRetries int, wait time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryWait = wait
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New creates a client for the API at baseURL, e.g. "https://example.com".
+// The /api/v1 prefix is added by the client.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		maxRetries: defaultMaxRetries,
+		retryWait:  defaultRetryWait,
+		userAgent:  "ghana-location-api-go",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// APIError is returned when the API responds with a non-2xx status. It
+// matches the sentinel error of its code with errors.Is, e.g.
+// errors.ErrNotFound for a 404.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    map[string]any
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("ghana location api: %d %s", e.StatusCode, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	if err := errors.ForCode(e.Code); err != nil {
+		return err
+	}
+	if e.StatusCode == http.StatusNotFound {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// get fetches path with the given query and decodes the JSON response into v.
+// Network errors, 429 and 5xx responses are retried with exponential backoff.
+// When ctx carries a date set with models.WithAsOf, the hierarchy is queried
+// as it stood on that date, and when it carries a language set with
+// models.WithLanguage, names are returned in that language.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	if date, ok := models.AsOf(ctx); ok {
+		if query == nil {
+			query = url.Values{}
+		}
+		query.Set("as_of", date.String())
+	}
+	if lang := models.Language(ctx); lang != models.DefaultLanguage {
+		if query == nil {
+			query = url.Values{}
+		}
+		query.Set("lang", lang)
+	}
+
+	endpoint := c.baseURL + "/api/v1" + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	wait := c.retryWait
+	for attempt := 0; ; attempt++ {
+		retry, err := c.do(ctx, endpoint, v)
+		if err == nil || !retry || attempt >= c.maxRetries {
+			return err
+		}
+
+		// Add jitter so concurrent clients do not retry in lockstep.
+		delay := wait + rand.N(wait/2+1)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		wait = min(wait*2, maxRetryWait)
+	}
+}
+
+// do performs a single request. It reports whether a failure may be retried.
+func (c *Client) do(ctx context.Context, endpoint string, v any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// A cancelled or expired context is final; anything else is a
+		// transport failure worth retrying.
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		var body errors.Response
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if json.Unmarshal(data, &body) == nil && body.Error.Message != "" {
+			apiErr.Code = body.Error.Code
+			apiErr.Message = body.Error.Message
+			apiErr.Details = body.Error.Details
+			apiErr.RequestID = body.Error.RequestID
+		}
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, apiErr
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return false, nil
+}
+
+// listQuery encodes the paging parameters of opts.
+func listQuery(opts models.ListOptions) url.Values {
+	query := url.Values{}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+	if opts.Sort != "" {
+		query.Set("sort", opts.Sort)
+	}
+	return query
+}
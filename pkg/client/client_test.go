@@ -0,0 +1,233 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ghana-location-api/data"
+	"github.com/ghana-location-api/pkg/client"
+	apierrors "github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/handlers"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/repositories/memory"
+)
+
+// newRouter returns the API router over the embedded dataset.
+func newRouter(t *testing.T) http.Handler {
+	t.Helper()
+	store, err := memory.NewStore(data.FS)
+	if err != nil {
+		t.Fatalf("failed to load dataset: %v", err)
+	}
+	r := chi.NewRouter()
+	r.NotFound(handlers.NotFound)
+	r.Route("/api/v1", handlers.Routes(memory.NewLocationService(store)))
+	return r
+}
+
+// newClient starts a server for handler and returns a client for it that
+// retries quickly.
+func newClient(t *testing.T, handler http.Handler) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return client.New(server.URL, client.WithRetries(3, time.Millisecond))
+}
+
+func TestGetters(t *testing.T) {
+	c := newClient(t, newRouter(t))
+	ctx := context.Background()
+
+	country, err := c.GetCountry(ctx, "GH")
+	if err != nil || country.Name != "Ghana" {
+		t.Errorf("GetCountry(GH) = %+v, %v", country, err)
+	}
+
+	region, err := c.GetRegion(ctx, "ashanti-region")
+	if err != nil || region.Name != "Ashanti Region" {
+		t.Errorf("GetRegion(ashanti-region) = %+v, %v", region, err)
+	}
+
+	district, err := c.GetDistrict(ctx, "sunyani-municipal")
+	if err != nil || district.Name != "Sunyani" {
+		t.Errorf("GetDistrict(sunyani-municipal) = %+v, %v", district, err)
+	}
+
+	constituency, err := c.GetConstituency(ctx, "sunyani-east")
+	if err != nil || constituency.Region == nil || constituency.Region.Slug != "bono-region" {
+		t.Errorf("GetConstituency(sunyani-east) = %+v, %v", constituency, err)
+	}
+
+	city, err := c.GetCity(ctx, "sunyani")
+	if err != nil || city.District == nil || city.District.Slug != "sunyani-west-district" {
+		t.Errorf("GetCity(sunyani) = %+v, %v", city, err)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	c := newClient(t, newRouter(t))
+	ctx := context.Background()
+
+	region, err := c.GetRegionTree(ctx, "ashanti-region", models.Expand{Districts: true, Cities: true})
+	if err != nil {
+		t.Fatalf("GetRegionTree(ashanti-region): %v", err)
+	}
+	if len(region.Districts) == 0 || region.Districts[0].Cities == nil || region.Districts[0].Constituencies != nil {
+		t.Errorf("GetRegionTree(ashanti-region) nested %d districts, first %+v", len(region.Districts), region.Districts)
+	}
+
+	district, err := c.GetDistrictTree(ctx, "sunyani-municipal", models.Expand{Districts: true, Constituencies: true})
+	if err != nil {
+		t.Fatalf("GetDistrictTree(sunyani-municipal): %v", err)
+	}
+	if len(district.Constituencies) == 0 || district.Cities != nil {
+		t.Errorf("GetDistrictTree(sunyani-municipal) = %+v", district)
+	}
+
+	plain, err := c.GetRegionTree(ctx, "ashanti-region", models.Expand{})
+	if err != nil || plain.Districts != nil {
+		t.Errorf("GetRegionTree(ashanti-region) without expand = %+v, %v", plain, err)
+	}
+}
+
+func TestAllCities(t *testing.T) {
+	c := newClient(t, newRouter(t))
+	ctx := context.Background()
+
+	first, err := c.ListCities(ctx, models.CityFilter{}, models.ListOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("ListCities: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for city, err := range c.AllCities(ctx, models.CityFilter{}, models.ListOptions{Limit: 500}) {
+		if err != nil {
+			t.Fatalf("AllCities: %v", err)
+		}
+		if seen[city.Slug] {
+			t.Fatalf("AllCities yielded %s twice", city.Slug)
+		}
+		seen[city.Slug] = true
+	}
+	if len(seen) != first.Total {
+		t.Errorf("AllCities yielded %d cities, want %d", len(seen), first.Total)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	router := newRouter(t)
+	failures := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusBadGateway}
+	var attempts atomic.Int32
+	c := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n := int(attempts.Add(1)); n <= len(failures) {
+			w.WriteHeader(failures[n-1])
+			return
+		}
+		router.ServeHTTP(w, r)
+	}))
+
+	region, err := c.GetRegion(context.Background(), "ashanti-region")
+	if err != nil {
+		t.Fatalf("GetRegion: %v", err)
+	}
+	if region.Slug != "ashanti-region" || attempts.Load() != 4 {
+		t.Errorf("got %s after %d attempts, want ashanti-region after 4", region.Slug, attempts.Load())
+	}
+}
+
+func TestRetriesGiveUp(t *testing.T) {
+	var attempts atomic.Int32
+	c := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	_, err := c.GetRegion(context.Background(), "ashanti-region")
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("err = %v, want a 500 APIError", err)
+	}
+	if attempts.Load() != 4 {
+		t.Errorf("made %d attempts, want 4", attempts.Load())
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	router := newRouter(t)
+	var attempts atomic.Int32
+	c := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		router.ServeHTTP(w, r)
+	}))
+
+	if _, err := c.GetRegion(context.Background(), "atlantis"); err == nil {
+		t.Fatal("GetRegion(atlantis) succeeded")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("made %d attempts, want 1", attempts.Load())
+	}
+}
+
+func TestCancel(t *testing.T) {
+	c := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetRegion(ctx, "ashanti-region"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestCancelDuringRetryWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	c := client.New(server.URL, client.WithRetries(3, time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.GetRegion(ctx, "ashanti-region"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("returned after %v, want on cancellation", elapsed)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	c := newClient(t, newRouter(t))
+
+	_, err := c.GetDistrict(context.Background(), "atlantis")
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != "not_found" || apiErr.Message == "" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if !errors.Is(err, apierrors.ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) = false", err)
+	}
+}
+
+func TestAliasRedirect(t *testing.T) {
+	c := newClient(t, newRouter(t))
+
+	region, err := c.GetRegion(context.Background(), "ashanti")
+	if err != nil {
+		t.Fatalf("GetRegion(ashanti): %v", err)
+	}
+	if region.Slug != "ashanti-region" {
+		t.Errorf("GetRegion(ashanti) = %s, want ashanti-region", region.Slug)
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+// Paginate yields every item of a paginated list, fetching pages with list
+// until no next cursor is returned. opts sets the page size, sort and the
+// starting cursor. Iteration stops after the first error, which is yielded
+// with a zero item.
+//
+//	pages := func(ctx context.Context, opts models.ListOptions) (*models.List[models.City], error) {
+//		return c.ListCities(ctx, filter, opts)
+//	}
+//	for city, err := range client.Paginate(ctx, opts, pages) { ... }
+func Paginate[T any](ctx context.Context, opts models.ListOptions, list func(context.Context, models.ListOptions) (*models.List[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			page, err := list(ctx, opts)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if page.NextCursor == nil {
+				return
+			}
+			opts.Cursor = *page.NextCursor
+		}
+	}
+}
+
+// AllRegions iterates over every region, fetching pages as needed.
+func (c *Client) AllRegions(ctx context.Context, opts models.ListOptions) iter.Seq2[models.Region, error] {
+	return Paginate(ctx, opts, c.ListRegions)
+}
+
+// AllDistricts iterates over every district matching filter.
+func (c *Client) AllDistricts(ctx context.Context, filter models.DistrictFilter, opts models.ListOptions) iter.Seq2[models.District, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts models.ListOptions) (*models.List[models.District], error) {
+		return c.ListDistricts(ctx, filter, opts)
+	})
+}
+
+// AllConstituencies iterates over every constituency matching filter.
+func (c *Client) AllConstituencies(ctx context.Context, filter models.ConstituencyFilter, opts models.ListOptions) iter.Seq2[models.Constituency, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts models.ListOptions) (*models.List[models.Constituency], error) {
+		return c.ListConstituencies(ctx, filter, opts)
+	})
+}
+
+// AllCities iterates over every city matching filter.
+func (c *Client) AllCities(ctx context.Context, filter models.CityFilter, opts models.ListOptions) iter.Seq2[models.City, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts models.ListOptions) (*models.List[models.City], error) {
+		return c.ListCities(ctx, filter, opts)
+	})
+}
+
+// AllPollingStations iterates over every polling station in a constituency.
+func (c *Client) AllPollingStations(ctx context.Context, constituencySlug string, opts models.ListOptions) iter.Seq2[models.PollingStation, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts models.ListOptions) (*models.List[models.PollingStation], error) {
+		return c.PollingStationsByConstituency(ctx, constituencySlug, opts)
+	})
+}
@@ -0,0 +1,319 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+func (c *Client) ListCountries(ctx context.Context, opts models.ListOptions) (*models.List[models.Country], error) {
+	var list models.List[models.Country]
+	if err := c.get(ctx, "/countries", listQuery(opts), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *Client) GetCountry(ctx context.Context, code string) (*models.Country, error) {
+	var country models.Country
+	if err := c.get(ctx, "/countries/"+url.PathEscape(code), nil, &country); err != nil {
+		return nil, err
+	}
+	return &country, nil
+}
+
+func (c *Client) ListRegions(ctx context.Context, opts models.ListOptions) (*models.List[models.Region], error) {
+	var list models.List[models.Region]
+	if err := c.get(ctx, "/regions", listQuery(opts), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *Client) GetRegion(ctx context.Context, slug string) (*models.Region, error) {
+	var region models.Region
+	if err := c.get(ctx, "/regions/"+url.PathEscape(slug), nil, &region); err != nil {
+		return nil, err
+	}
+	return &region, nil
+}
+
+// GetRegionTree returns a region with the child collections selected by
+// expand nested into it.
+func (c *Client) GetRegionTree(ctx context.Context, slug string, expand models.Expand) (*models.RegionNode, error) {
+	var region models.RegionNode
+	if err := c.get(ctx, "/regions/"+url.PathEscape(slug), expandQuery(expand), &region); err != nil {
+		return nil, err
+	}
+	return &region, nil
+}
+
+func (c *Client) RegionBoundary(ctx context.Context, slug string) (*models.Boundary, error) {
+	var boundary models.Boundary
+	if err := c.get(ctx, "/regions/"+url.PathEscape(slug)+"/boundary", nil, &boundary); err != nil {
+		return nil, err
+	}
+	return &boundary, nil
+}
+
+func (c *Client) DistrictsByRegion(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.District], error) {
+	var list models.List[models.District]
+	if err := c.get(ctx, "/regions/"+url.PathEscape(regionSlug)+"/districts", listQuery(opts), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *Client) ListDistricts(ctx context.Context, filter models.DistrictFilter, opts models.ListOptions) (*models.List[models.District], error) {
+	query := listQuery(opts)
+	setIfNotEmpty(query, "region", filter.RegionSlug)
+	setIfNotEmpty(query, "type", filter.Type)
+
+	var list models.List[models.District]
+	if err := c.get(ctx, "/districts", query, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *Client) GetDistrict(ctx context.Context, slug string) (*models.District, error) {
+	var district models.District
+	if err := c.get(ctx, "/districts/"+url.PathEscape(slug), nil, &district); err != nil {
+		return nil, err
+	}
+	return &district, nil
+}
+
+// GetDistrictTree returns a district with the child collections selected by
+// expand nested into it. expand.Districts does not apply to a district and
+// is ignored.
+func (c *Client) GetDistrictTree(ctx context.Context, slug string, expand models.Expand) (*models.DistrictNode, error) {
+	expand.Districts = false
+
+	var district models.DistrictNode
+	if err := c.get(ctx, "/districts/"+url.PathEscape(slug), expandQuery(expand), &district); err != nil {
+		return nil, err
+	}
+	return &district, nil
+}
+
+func (c *Client) DistrictBoundary(ctx context.Context, slug string) (*models.Boundary, error) {
+	var boundary models.Boundary
+	if err := c.get(ctx, "/districts/"+url.PathEscape(slug)+"/boundary", nil, &boundary); err != nil {
+		return nil, err
+	}
+	return &boundary, nil
+}
+
+// DistrictLineage returns the districts a district was split, merged or
+// renamed from and into.
+func (c *Client) DistrictLineage(ctx context.Context, slug string) (*models.Lineage, error) {
+	var lineage models.Lineage
+	if err := c.get(ctx, "/districts/"+url.PathEscape(slug)+"/lineage", nil, &lineage); err != nil {
+		return nil, err
+	}
+	return &lineage, nil
+}
+
+func (c *Client) ConstituenciesByDistrict(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	var list models.List[models.Constituency]
+	if err := c.get(ctx, "/districts/"+url.PathEscape(districtSlug)+"/constituencies", listQuery(opts), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *Client) ListConstituencies(ctx context.Context, filter models.ConstituencyFilter, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	query := listQuery(opts)
+	setIfNotEmpty(query, "region", filter.RegionSlug)
+	setIfNotEmpty(query, "district", filter.DistrictSlug)
+
+	var list models.List[models.Constituency]
+	if err := c.get(ctx, "/constituencies", query, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *Client) ConstituenciesByRegion(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	var list models.List[models.Constituency]
+	if err := c.get(ctx, "/regions/"+url.PathEscape(regionSlug)+"/constituencies", listQuery(opts), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetConstituency returns a constituency with its district and region.
+func (c *Client) GetConstituency(ctx context.Context, slug string) (*models.ConstituencyDetail, error) {
+	var constituency models.ConstituencyDetail
+	if err := c.get(ctx, "/constituencies/"+url.PathEscape(slug), nil, &constituency); err != nil {
+		return nil, err
+	}
+	return &constituency, nil
+}
+
+// ConstituencyLineage returns the constituencies a constituency was split,
+// merged or renamed from and into.
+func (c *Client) ConstituencyLineage(ctx context.Context, slug string) (*models.Lineage, error) {
+	var lineage models.Lineage
+	if err := c.get(ctx, "/constituencies/"+url.PathEscape(slug)+"/lineage", nil, &lineage); err != nil {
+		return nil, err
+	}
+	return &lineage, nil
+}
+
+func (c *Client) PollingStationsByConstituency(ctx context.Context, constituencySlug string, opts models.ListOptions) (*models.List[models.PollingStation], error) {
+	var list models.List[models.PollingStation]
+	if err := c.get(ctx, "/constituencies/"+url.PathEscape(constituencySlug)+"/polling-stations", listQuery(opts), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *Client) GetCity(ctx context.Context, slug string) (*models.CityDetail, error) {
+	var city models.CityDetail
+	if err := c.get(ctx, "/cities/"+url.PathEscape(slug), nil, &city); err != nil {
+		return nil, err
+	}
+	return &city, nil
+}
+
+// NearbyCities returns the cities within radiusKm of a city. Zero radiusKm or
+// limit leaves the server default.
+func (c *Client) NearbyCities(ctx context.Context, slug string, radiusKm float64, limit int) (*models.NearbyCities, error) {
+	params := url.Values{}
+	if radiusKm > 0 {
+		params.Set("radius_km", strconv.FormatFloat(radiusKm, 'f', -1, 64))
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var nearby models.NearbyCities
+	if err := c.get(ctx, "/cities/"+url.PathEscape(slug)+"/nearby", params, &nearby); err != nil {
+		return nil, err
+	}
+	return &nearby, nil
+}
+
+func (c *Client) Distance(ctx context.Context, fromSlug, toSlug string) (*models.CityDistance, error) {
+	params := url.Values{"from": {fromSlug}, "to": {toSlug}}
+
+	var distance models.CityDistance
+	if err := c.get(ctx, "/distance", params, &distance); err != nil {
+		return nil, err
+	}
+	return &distance, nil
+}
+
+func (c *Client) ListCities(ctx context.Context, filter models.CityFilter, opts models.ListOptions) (*models.List[models.City], error) {
+	query := listQuery(opts)
+	setIfNotEmpty(query, "region", filter.RegionSlug)
+	setIfNotEmpty(query, "district", filter.DistrictSlug)
+	if filter.HasCoordinates != nil {
+		query.Set("has_coordinates", strconv.FormatBool(*filter.HasCoordinates))
+	}
+
+	var list models.List[models.City]
+	if err := c.get(ctx, "/cities", query, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (c *Client) CitiesByDistrict(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.City], error) {
+	return c.ListCities(ctx, models.CityFilter{DistrictSlug: districtSlug}, opts)
+}
+
+func (c *Client) GetPollingStation(ctx context.Context, code string) (*models.PollingStation, error) {
+	var station models.PollingStation
+	if err := c.get(ctx, "/polling-stations/"+url.PathEscape(code), nil, &station); err != nil {
+		return nil, err
+	}
+	return &station, nil
+}
+
+// DecodePollingStationCode decodes a polling station code into its parts and
+// the region and constituency they refer to.
+func (c *Client) DecodePollingStationCode(ctx context.Context, code string) (*models.PollingStationCode, error) {
+	var decoded models.PollingStationCode
+	if err := c.get(ctx, "/polling-station-codes/"+url.PathEscape(code)+"/decode", nil, &decoded); err != nil {
+		return nil, err
+	}
+	return &decoded, nil
+}
+
+// GetHierarchy returns every region with its districts and constituencies,
+// and cities too when includeCities is set.
+func (c *Client) GetHierarchy(ctx context.Context, includeCities bool) (*models.Hierarchy, error) {
+	query := url.Values{}
+	if includeCities {
+		query.Set("expand", "cities")
+	}
+
+	var hierarchy models.Hierarchy
+	if err := c.get(ctx, "/hierarchy", query, &hierarchy); err != nil {
+		return nil, err
+	}
+	return &hierarchy, nil
+}
+
+// Search matches query against location names. types restricts the result
+// types (region, district, constituency, city); a zero limit uses the API default.
+func (c *Client) Search(ctx context.Context, query string, types []string, limit int) ([]models.SearchResult, error) {
+	params := url.Values{"q": {query}}
+	setIfNotEmpty(params, "type", strings.Join(types, ","))
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	results := []models.SearchResult{}
+	if err := c.get(ctx, "/search", params, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Reverse finds the region, district and nearest cities for a coordinate.
+// A zero limit uses the API default.
+func (c *Client) Reverse(ctx context.Context, lat, lng float64, limit int) (*models.ReverseGeocodeResult, error) {
+	params := url.Values{
+		"lat": {strconv.FormatFloat(lat, 'f', -1, 64)},
+		"lng": {strconv.FormatFloat(lng, 'f', -1, 64)},
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var result models.ReverseGeocodeResult
+	if err := c.get(ctx, "/reverse", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// expandQuery encodes expand as the API's expand parameter.
+func expandQuery(expand models.Expand) url.Values {
+	var names []string
+	if expand.Districts {
+		names = append(names, "districts")
+	}
+	if expand.Constituencies {
+		names = append(names, "constituencies")
+	}
+	if expand.Cities {
+		names = append(names, "cities")
+	}
+	query := url.Values{}
+	setIfNotEmpty(query, "expand", strings.Join(names, ","))
+	return query
+}
+
+func setIfNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
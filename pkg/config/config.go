@@ -1,39 +1,115 @@
-package config
-
-import (
-	"fmt"
-	"os"
-	"strconv"
-
-	"github.com/joho/godotenv"
-)
-
-type Config struct {
-	DatabaseURL string
-	Port        int
-}
-
-func Load() (*Config, error) {
-	// Load .env file if it exists (ignore error if file doesn't exist)
-	_ = godotenv.Load()
-
-	databaseURL := os.Getenv("DATABASE_URL")
-	if databaseURL == "" {
-		return nil, fmt.Errorf("DATABASE_URL environment variable is required")
-	}
-
-	portStr := os.Getenv("PORT")
-	port := 8080
-	if portStr != "" {
-		var err error
-		port, err = strconv.Atoi(portStr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid PORT value: %w", err)
-		}
-	}
-
-	return &Config{
-		DatabaseURL: databaseURL,
-		Port:        port,
-	}, nil
-}
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Data sources the API can serve from.
+const (
+	DataSourcePostgres = "postgres"
+	DataSourceEmbedded = "embedded"
+)
+
+// Stores for the query cache in front of PostgreSQL.
+const (
+	CacheLRU   = "lru"
+	CacheRedis = "redis"
+	CacheNone  = "none"
+)
+
+type Config struct {
+	DatabaseURL string
+	DataSource  string
+	Port        int
+
+	Cache     string
+	CacheSize int           // entries held by the LRU
+	CacheTTL  time.Duration // expiry of Redis entries
+	RedisURL  string
+}
+
+func Load() (*Config, error) {
+	// Load .env file if it exists (ignore error if file doesn't exist)
+	_ = godotenv.Load()
+
+	// Trim any whitespace that might have been accidentally included
+	databaseURL := strings.TrimSpace(os.Getenv("DATABASE_URL"))
+
+	// Default to PostgreSQL when a database is configured, otherwise serve the embedded dataset
+	dataSource := os.Getenv("DATA_SOURCE")
+	if dataSource == "" {
+		dataSource = DataSourceEmbedded
+		if databaseURL != "" {
+			dataSource = DataSourcePostgres
+		}
+	}
+
+	switch dataSource {
+	case DataSourcePostgres:
+		if databaseURL == "" {
+			return nil, fmt.Errorf("DATABASE_URL environment variable is required")
+		}
+	case DataSourceEmbedded:
+	default:
+		return nil, fmt.Errorf("invalid DATA_SOURCE value: %q (expected %q or %q)", dataSource, DataSourcePostgres, DataSourceEmbedded)
+	}
+
+	portStr := os.Getenv("PORT")
+	port := 8080
+	if portStr != "" {
+		var err error
+		port, err = strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PORT value: %w", err)
+		}
+	}
+
+	cache := os.Getenv("CACHE")
+	if cache == "" {
+		cache = CacheLRU
+	}
+	redisURL := strings.TrimSpace(os.Getenv("REDIS_URL"))
+	switch cache {
+	case CacheLRU, CacheNone:
+	case CacheRedis:
+		if redisURL == "" {
+			return nil, fmt.Errorf("REDIS_URL environment variable is required with CACHE=redis")
+		}
+	default:
+		return nil, fmt.Errorf("invalid CACHE value: %q (expected %q, %q or %q)", cache, CacheLRU, CacheRedis, CacheNone)
+	}
+
+	cacheSize := 10000
+	if sizeStr := os.Getenv("CACHE_SIZE"); sizeStr != "" {
+		var err error
+		cacheSize, err = strconv.Atoi(sizeStr)
+		if err != nil || cacheSize < 1 {
+			return nil, fmt.Errorf("invalid CACHE_SIZE value: %q", sizeStr)
+		}
+	}
+
+	cacheTTL := time.Hour
+	if ttlStr := os.Getenv("CACHE_TTL"); ttlStr != "" {
+		var err error
+		cacheTTL, err = time.ParseDuration(ttlStr)
+		if err != nil || cacheTTL <= 0 {
+			return nil, fmt.Errorf("invalid CACHE_TTL value: %q", ttlStr)
+		}
+	}
+
+	return &Config{
+		DatabaseURL: databaseURL,
+		DataSource:  dataSource,
+		Port:        port,
+		Cache:       cache,
+		CacheSize:   cacheSize,
+		CacheTTL:    cacheTTL,
+		RedisURL:    redisURL,
+	}, nil
+}
@@ -0,0 +1,19 @@
+package dataset
+
+// AlternateNamesFile lists the other names regions, districts, constituencies
+// and cities are known by.
+const AlternateNamesFile = "alternate-names.json"
+
+// AlternateNameTypes lists the valid values of AlternateName.Type.
+var AlternateNameTypes = []string{"historical", "variant", "abbreviation"}
+
+// AlternateName is another name the record of Kind with Slug is known by:
+// a historical name, a spelling variant or an abbreviation. Lookups match it
+// by its key, Slugify(Name), so "Brong Ahafo" and "brong-ahafo" are the same
+// name.
+type AlternateName struct {
+	Kind string `json:"kind"` // region, district, constituency, city
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	Type string `json:"type"` // historical, variant, abbreviation
+}
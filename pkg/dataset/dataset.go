@@ -0,0 +1,201 @@
+// Package dataset reads the normalized location data files in data/.
+package dataset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+)
+
+type Country struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type Region struct {
+	Name    string  `json:"name"`
+	Slug    string  `json:"slug"`
+	Capital *string `json:"capital,omitempty"`
+	// ECLetter is the letter Electoral Commission polling station codes in
+	// the region start with. Regions retired before the current codes have none.
+	ECLetter string `json:"ec_letter,omitempty"`
+	Validity
+}
+
+type District struct {
+	Name       string  `json:"name"`
+	Slug       string  `json:"slug"`
+	Type       string  `json:"type"`
+	Capital    *string `json:"capital,omitempty"`
+	RegionSlug string  `json:"region_slug"`
+	// FormerRegions are the regions the district was in before RegionSlug,
+	// oldest first, as for the districts moved to the regions created in 2019.
+	FormerRegions []FormerRegion `json:"former_regions,omitempty"`
+	Validity
+}
+
+// FormerRegion is a region a district belonged to until ValidTo, a
+// YYYY-MM-DD date, when it moved to the next region in its history.
+type FormerRegion struct {
+	RegionSlug string `json:"region_slug"`
+	ValidTo    string `json:"valid_to"`
+}
+
+type Constituency struct {
+	Name         string  `json:"name"`
+	Slug         string  `json:"slug"`
+	RegionSlug   string  `json:"region_slug"`
+	DistrictSlug *string `json:"district_slug,omitempty"`
+	// ECNumber is the two-digit number that follows the region letter in
+	// the constituency's polling station codes, when known. Constituencies
+	// split from one another keep the number they had, so it can be shared.
+	ECNumber *int `json:"ec_number,omitempty"`
+	Validity
+}
+
+// Validity is the period a region, district or constituency existed in, as
+// YYYY-MM-DD dates: from ValidFrom up to, but not including, ValidTo. Records
+// without ValidFrom existed before records begin; records without ValidTo
+// still exist.
+type Validity struct {
+	ValidFrom *string `json:"valid_from,omitempty"`
+	ValidTo   *string `json:"valid_to,omitempty"`
+}
+
+// Dates parses the period's dates.
+func (v Validity) Dates() (from, to *time.Time, err error) {
+	if from, err = parseDate(v.ValidFrom); err != nil {
+		return nil, nil, fmt.Errorf("invalid valid_from: %w", err)
+	}
+	if to, err = parseDate(v.ValidTo); err != nil {
+		return nil, nil, fmt.Errorf("invalid valid_to: %w", err)
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, fmt.Errorf("valid_from %s is not before valid_to %s", *v.ValidFrom, *v.ValidTo)
+	}
+	return from, to, nil
+}
+
+// Retired reports whether the record no longer exists.
+func (v Validity) Retired() bool {
+	return v.ValidTo != nil
+}
+
+func parseDate(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+type City struct {
+	Name         string   `json:"name"`
+	Slug         string   `json:"slug"`
+	Lat          *float64 `json:"lat,omitempty"`
+	Lng          *float64 `json:"lng,omitempty"`
+	DistrictSlug string   `json:"district_slug"`
+}
+
+// Dataset holds the contents of the JSON data files, in file order.
+type Dataset struct {
+	Countries      []Country
+	Regions        []Region
+	Districts      []District
+	Constituencies []Constituency
+	Cities         []City
+	Lineage        []LineageEvent
+	AlternateNames []AlternateName
+	LocalizedNames []LocalizedName
+
+	// RegionConstituencies maps region names without the " Region" suffix
+	// (e.g. "Greater Accra") to the names of their constituencies.
+	RegionConstituencies map[string][]string
+}
+
+// Load reads the JSON data files from the root of fsys, e.g. os.DirFS("data")
+// or the embedded data.FS.
+func Load(fsys fs.FS) (*Dataset, error) {
+	var ds Dataset
+	files := []struct {
+		name string
+		v    any
+	}{
+		{"countries.json", &ds.Countries},
+		{"regions.json", &ds.Regions},
+		{"districts.json", &ds.Districts},
+		{"constituencies.json", &ds.Constituencies},
+		{"cities.json", &ds.Cities},
+		{"region-constituencies.json", &ds.RegionConstituencies},
+		{LineageFile, &ds.Lineage},
+		{AlternateNamesFile, &ds.AlternateNames},
+		{LocalizedNamesFile, &ds.LocalizedNames},
+	}
+	for _, file := range files {
+		if err := readJSON(fsys, file.name, file.v); err != nil {
+			return nil, err
+		}
+	}
+	return &ds, nil
+}
+
+func readJSON(fsys fs.FS, name string, v any) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// ConstituencyRegions maps each constituency slug to the slug of its region
+// as listed in region-constituencies.json. Constituencies missing from that
+// file keep the region_slug of constituencies.json.
+func (ds *Dataset) ConstituencyRegions() map[string]string {
+	listed, _ := ds.listedConstituencyRegions()
+
+	regions := make(map[string]string, len(ds.Constituencies))
+	for _, constituency := range ds.Constituencies {
+		regions[constituency.Slug] = constituency.RegionSlug
+		candidates := listed[normalizeECName(constituency.Name)]
+		if len(candidates) == 1 {
+			regions[constituency.Slug] = candidates[0]
+		}
+	}
+	return regions
+}
+
+// listedConstituencyRegions maps normalized constituency names in
+// region-constituencies.json to the slugs of the regions they are listed
+// under. Constituency names are only unique within a region, so a name may
+// map to several regions. Region names that match no region are returned
+// separately.
+func (ds *Dataset) listedConstituencyRegions() (map[string][]string, []string) {
+	regionByName := make(map[string]string)
+	for _, region := range ds.Regions {
+		regionByName[normalizeECName(strings.TrimSuffix(region.Name, " Region"))] = region.Slug
+	}
+
+	listed := make(map[string][]string)
+	var unknown []string
+	for name, constituencies := range ds.RegionConstituencies {
+		regionSlug, exists := regionByName[normalizeECName(name)]
+		if !exists {
+			unknown = append(unknown, name)
+			continue
+		}
+		for _, constituency := range constituencies {
+			key := normalizeECName(constituency)
+			listed[key] = append(listed[key], regionSlug)
+		}
+	}
+	return listed, unknown
+}
@@ -0,0 +1,27 @@
+package dataset
+
+// DistrictRegion is a period in which a district belonged to a region. The
+// periods of a district follow each other and together span its validity:
+// the first starts with the district and the last, in its current region,
+// ends with it.
+type DistrictRegion struct {
+	DistrictSlug string
+	RegionSlug   string
+	Validity
+}
+
+// DistrictRegions lists the regions each district belonged to, oldest first:
+// its former regions, then its current region from the date it moved there.
+func (ds *Dataset) DistrictRegions() []DistrictRegion {
+	var periods []DistrictRegion
+	for _, district := range ds.Districts {
+		from := district.ValidFrom
+		for _, former := range district.FormerRegions {
+			to := former.ValidTo
+			periods = append(periods, DistrictRegion{DistrictSlug: district.Slug, RegionSlug: former.RegionSlug, Validity: Validity{ValidFrom: from, ValidTo: &to}})
+			from = &to
+		}
+		periods = append(periods, DistrictRegion{DistrictSlug: district.Slug, RegionSlug: district.RegionSlug, Validity: Validity{ValidFrom: from, ValidTo: district.ValidTo}})
+	}
+	return periods
+}
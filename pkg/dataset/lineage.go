@@ -0,0 +1,50 @@
+package dataset
+
+// LineageFile lists the split, merge and rename events between districts and
+// between constituencies.
+const LineageFile = "lineage.json"
+
+// Lineage event types.
+const (
+	LineageSplit  = "split"
+	LineageMerge  = "merge"
+	LineageRename = "rename"
+)
+
+// LineageKinds lists the kinds of record lineage events apply to.
+var LineageKinds = []string{"district", "constituency"}
+
+// LineageEvent is a change to the districts or constituencies: on Date the
+// records in From were split, merged or renamed into the records in To. A
+// record that lives on with a smaller area after part of it is carved out
+// into a new one, as Accra Metro did in 2018, is only listed in From.
+type LineageEvent struct {
+	Type string   `json:"type"` // split, merge, rename
+	Kind string   `json:"kind"` // district, constituency
+	Date string   `json:"date"`
+	From []string `json:"from"`
+	To   []string `json:"to"`
+}
+
+// LineageLink links a record to one of the records an event turned it into.
+type LineageLink struct {
+	Type        string
+	Kind        string
+	Date        string
+	Predecessor string
+	Successor   string
+}
+
+// LineageLinks flattens the lineage events into a link from every record an
+// event started from to every record it produced, in file order.
+func (ds *Dataset) LineageLinks() []LineageLink {
+	var links []LineageLink
+	for _, event := range ds.Lineage {
+		for _, from := range event.From {
+			for _, to := range event.To {
+				links = append(links, LineageLink{Type: event.Type, Kind: event.Kind, Date: event.Date, Predecessor: from, Successor: to})
+			}
+		}
+	}
+	return links
+}
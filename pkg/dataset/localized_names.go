@@ -0,0 +1,20 @@
+package dataset
+
+import "regexp"
+
+// LocalizedNamesFile lists the names of regions, districts, constituencies
+// and cities in Ghanaian languages other than English.
+const LocalizedNamesFile = "localized-names.json"
+
+// LanguagePattern matches the language codes of localized names: a lower-case
+// ISO 639 code such as "tw", "gaa", "ee" or "dag".
+var LanguagePattern = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// LocalizedName is the name of the record of Kind with Slug in the language
+// Lang. Records without a name in a language are shown by their English name.
+type LocalizedName struct {
+	Kind string `json:"kind"` // region, district, constituency, city
+	Slug string `json:"slug"`
+	Lang string `json:"lang"`
+	Name string `json:"name"`
+}
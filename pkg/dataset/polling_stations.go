@@ -0,0 +1,236 @@
+package dataset
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+)
+
+// PollingStationsFile is the Electoral Commission polling station list in data/.
+const PollingStationsFile = "polling_station.txt"
+
+// pollingStationLine matches a data row: "<row no> <code> <name> <constituency> <district> <region>".
+// Page headers and footers copied from the EC PDF do not match and are skipped.
+var pollingStationLine = regexp.MustCompile(`^\s*[\d,]+\s+([A-Z]\d{6}[A-Z]?)\s+(.+?)\s*$`)
+
+// districtNameSuffixes are the forms the EC appends to district names.
+var districtNameSuffixes = []string{"", " MUNICIPAL", " METRO", " METROPOLITAN", " DISTRICT"}
+
+// PollingStation is a row of the EC polling station list resolved against the
+// dataset. DistrictSlug and ConstituencySlug are nil when the names in the row
+// do not match the dataset. SharedPrefix lists the constituencies that share
+// the code's prefix when the constituency name was not recognized and the
+// prefix cannot tell them apart.
+type PollingStation struct {
+	Code             string
+	Name             string
+	RegionSlug       string
+	DistrictSlug     *string
+	ConstituencySlug *string
+	SharedPrefix     []string
+}
+
+type pollingStationRow struct {
+	code       string
+	regionSlug string
+	tokens     []string
+}
+
+// pollingStationLookup maps region letters to region slugs and holds the
+// names the parser resolves rows against, keyed by region slug.
+type pollingStationLookup struct {
+	regionLetters  map[string]string
+	regionNames    map[string]string
+	districts      map[string]map[string]string
+	constituencies map[string]map[string]string
+
+	// constituencyPrefixes maps code prefixes (region letter plus EC
+	// constituency number) to the slugs of the constituencies using them.
+	constituencyPrefixes map[string][]string
+}
+
+func newPollingStationLookup(ds *Dataset) *pollingStationLookup {
+	lookup := &pollingStationLookup{
+		regionLetters:  make(map[string]string),
+		regionNames:    make(map[string]string),
+		districts:      make(map[string]map[string]string),
+		constituencies: make(map[string]map[string]string),
+
+		constituencyPrefixes: make(map[string][]string),
+	}
+	letters := make(map[string]string)
+	for _, region := range ds.Regions {
+		letters[region.Slug] = region.ECLetter
+		lookup.regionLetters[region.ECLetter] = region.Slug
+		lookup.regionNames[region.Slug] = normalizeECName(strings.TrimSuffix(region.Name, " Region"))
+	}
+	for _, district := range ds.Districts {
+		if lookup.districts[district.RegionSlug] == nil {
+			lookup.districts[district.RegionSlug] = make(map[string]string)
+		}
+		name := normalizeECName(district.Name)
+		for _, suffix := range districtNameSuffixes {
+			if _, exists := lookup.districts[district.RegionSlug][name+suffix]; !exists {
+				lookup.districts[district.RegionSlug][name+suffix] = district.Slug
+			}
+		}
+	}
+	for _, constituency := range ds.Constituencies {
+		if lookup.constituencies[constituency.RegionSlug] == nil {
+			lookup.constituencies[constituency.RegionSlug] = make(map[string]string)
+		}
+		lookup.constituencies[constituency.RegionSlug][normalizeECName(constituency.Name)] = constituency.Slug
+		if constituency.ECNumber != nil {
+			prefix := fmt.Sprintf("%s%02d", letters[constituency.RegionSlug], *constituency.ECNumber)
+			lookup.constituencyPrefixes[prefix] = append(lookup.constituencyPrefixes[prefix], constituency.Slug)
+		}
+	}
+
+	return lookup
+}
+
+// LoadPollingStations reads the EC polling station list from fsys and splits
+// each row into station name, constituency and district, resolved against ds.
+//
+// The source has no column separators, so the trailing "<constituency> <district>"
+// words are found in two ways: by matching known district and constituency names,
+// and by taking the words shared by every station with the same code prefix
+// (region letter plus constituency number), which is used to trim the station
+// name when the constituency is not in data/constituencies.json. Stations whose
+// constituency name is not recognized take the constituency with their code's
+// ec_number, unless several constituencies share it.
+func LoadPollingStations(fsys fs.FS, ds *Dataset) ([]PollingStation, error) {
+	file, err := fsys.Open(PollingStationsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", PollingStationsFile, err)
+	}
+	defer file.Close()
+
+	lookup := newPollingStationLookup(ds)
+
+	var rows []pollingStationRow
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		match := pollingStationLine.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		code, text := match[1], strings.ToUpper(match[2])
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		regionSlug, exists := lookup.regionLetters[code[:1]]
+		if !exists {
+			return nil, fmt.Errorf("unknown region letter in polling station code %s", code)
+		}
+
+		// The region is sometimes glued to the district name ("ABREMCENTRAL").
+		text = strings.TrimSpace(strings.TrimSuffix(text, lookup.regionNames[regionSlug]))
+		rows = append(rows, pollingStationRow{code: code, regionSlug: regionSlug, tokens: strings.Fields(text)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", PollingStationsFile, err)
+	}
+
+	groupTails := make(map[string][]string)
+	for _, row := range rows {
+		prefix := row.code[:3]
+		tail, exists := groupTails[prefix]
+		if !exists {
+			groupTails[prefix] = row.tokens
+			continue
+		}
+		groupTails[prefix] = commonSuffix(tail, row.tokens)
+	}
+
+	stations := make([]PollingStation, 0, len(rows))
+	for _, row := range rows {
+		stations = append(stations, resolvePollingStation(row, len(groupTails[row.code[:3]]), lookup))
+	}
+
+	return stations, nil
+}
+
+func resolvePollingStation(row pollingStationRow, tailLen int, lookup *pollingStationLookup) PollingStation {
+	station := PollingStation{Code: row.code, RegionSlug: row.regionSlug}
+	tokens := row.tokens
+	nameEnd := len(tokens) - tailLen
+
+	districtLen, districtSlug := longestSuffixMatch(tokens, lookup.districts[row.regionSlug])
+	if districtLen > 0 {
+		station.DistrictSlug = &districtSlug
+
+		constituencyTokens := tokens[:len(tokens)-districtLen]
+		constituencyLen, constituencySlug := longestSuffixMatch(constituencyTokens, lookup.constituencies[row.regionSlug])
+		if constituencyLen > 0 {
+			station.ConstituencySlug = &constituencySlug
+			nameEnd = len(constituencyTokens) - constituencyLen
+		}
+	} else if nameEnd > 0 {
+		// Unknown district spelling: the constituency starts where the shared tail starts.
+		tail := tokens[nameEnd:]
+		for i := len(tail); i > 0; i-- {
+			if slug, exists := lookup.constituencies[row.regionSlug][normalizeECName(strings.Join(tail[:i], " "))]; exists {
+				station.ConstituencySlug = &slug
+				break
+			}
+		}
+	}
+
+	if station.ConstituencySlug == nil {
+		slugs := lookup.constituencyPrefixes[row.code[:3]]
+		if len(slugs) == 1 {
+			station.ConstituencySlug = &slugs[0]
+		} else if len(slugs) > 1 {
+			station.SharedPrefix = slugs
+		}
+	}
+
+	if nameEnd <= 0 {
+		nameEnd = len(tokens) - districtLen
+	}
+	station.Name = strings.Join(tokens[:nameEnd], " ")
+	if station.Name == "" {
+		station.Name = strings.Join(tokens, " ")
+	}
+
+	return station
+}
+
+// longestSuffixMatch returns the number of trailing tokens forming the longest
+// name found in names, together with the slug it maps to.
+func longestSuffixMatch(tokens []string, names map[string]string) (int, string) {
+	bestLen, bestSlug := 0, ""
+	for i := len(tokens) - 1; i > 0; i-- {
+		if slug, exists := names[normalizeECName(strings.Join(tokens[i:], " "))]; exists {
+			bestLen, bestSlug = len(tokens)-i, slug
+		}
+	}
+	return bestLen, bestSlug
+}
+
+func commonSuffix(a, b []string) []string {
+	i := 0
+	for i < len(a) && i < len(b) && a[len(a)-1-i] == b[len(b)-1-i] {
+		i++
+	}
+	return a[len(a)-i:]
+}
+
+// normalizeECName upper-cases a name and treats hyphens, slashes and other
+// punctuation as spaces so "Afigya-kwabre North" matches "AFIGYA KWABRE NORTH".
+func normalizeECName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '/', '.', ',', '(', ')', '\'':
+			return ' '
+		}
+		return r
+	}, strings.ToUpper(name))
+	return strings.Join(strings.Fields(name), " ")
+}
@@ -0,0 +1,58 @@
+package dataset
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadPollingStationsSharedPrefix(t *testing.T) {
+	one, two := 1, 2
+	ds := &Dataset{
+		Regions:   []Region{{Name: "Central Region", Slug: "central-region", ECLetter: "B"}},
+		Districts: []District{{Name: "Cape Coast", Slug: "cape-coast-metro", Type: "metro", RegionSlug: "central-region"}},
+		Constituencies: []Constituency{
+			{Name: "Komenda Edina Eguafo Abrem", Slug: "komenda-edina-eguafo-abrem", RegionSlug: "central-region", ECNumber: &one},
+			{Name: "Cape Coast South", Slug: "cape-coast-south", RegionSlug: "central-region", ECNumber: &two},
+			{Name: "Cape Coast North", Slug: "cape-coast-north", RegionSlug: "central-region", ECNumber: &two},
+		},
+	}
+	fsys := fstest.MapFS{PollingStationsFile: {Data: []byte(
+		" 1 B010101 M/A PRI. SCH. DOMINASE EAST KOMENDA EDINA EGUAFO ABREM KOMENDA CENTRAL\n" +
+			" 2 B010102 M/A JHS DOMINASE KEEA KOMENDA CENTRAL\n" +
+			" 3 B020101 GHANA NATIONAL COLLEGE CAPE COAST SOUTH CAPE COAST CENTRAL\n" +
+			" 4 B023101 MOSQUE NKANFOA CAPE COAST NORTH CAPE COAST CENTRAL\n" +
+			" 5 B020102 PRESBY PRIM SCH CAPE COAST CENTRE CAPE COAST CENTRAL\n",
+	)}}
+
+	stations, err := LoadPollingStations(fsys, ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]struct {
+		constituency string
+		shared       []string
+	}{
+		// A recognized name wins over the prefix.
+		"B010101": {constituency: "komenda-edina-eguafo-abrem"},
+		"B020101": {constituency: "cape-coast-south"},
+		"B023101": {constituency: "cape-coast-north"},
+		// An unrecognized name falls back to a prefix of one constituency,
+		"B010102": {constituency: "komenda-edina-eguafo-abrem"},
+		// but not to a shared one.
+		"B020102": {shared: []string{"cape-coast-south", "cape-coast-north"}},
+	}
+	if len(stations) != len(want) {
+		t.Fatalf("loaded %d stations, want %d", len(stations), len(want))
+	}
+	for _, station := range stations {
+		w := want[station.Code]
+		got := ""
+		if station.ConstituencySlug != nil {
+			got = *station.ConstituencySlug
+		}
+		if got != w.constituency || !slices.Equal(station.SharedPrefix, w.shared) {
+			t.Errorf("%s: constituency %q, shared prefix %v, want %q, %v", station.Code, got, station.SharedPrefix, w.constituency, w.shared)
+		}
+	}
+}
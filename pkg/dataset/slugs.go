@@ -0,0 +1,92 @@
+package dataset
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	nonSlugChars       = regexp.MustCompile(`[^a-z0-9]+`)
+	districtTypeSuffix = regexp.MustCompile(`-(district|municipal|metro)$`)
+)
+
+// Slugify lower-cases value and joins its runs of letters and digits with hyphens.
+func Slugify(value string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(value), "-"), "-")
+}
+
+// CityKey identifies a city the way the cities table does: by district and name.
+func CityKey(districtSlug, name string) string {
+	return districtSlug + "/" + name
+}
+
+// CitySlugs assigns every named city a unique slug, keyed by CityKey. Cities
+// in existing, also keyed by CityKey, keep the slug already assigned to them,
+// as in the database, so adding a namesake never renames a city. Any other
+// city keeps the slug from cities.json (or one made from its name) when no
+// other city shares it; cities that share a slug are all qualified by their
+// district, e.g. "beposo-amansie-west", and numbered if that still collides.
+// Later entries for the same district and name replace earlier ones, as when
+// seeding. Without existing slugs, slugs depend only on the file contents.
+func (ds *Dataset) CitySlugs(existing map[string]string) map[string]string {
+	type entry struct {
+		key, base, district string
+	}
+	var entries []entry
+	index := make(map[string]int)
+	for _, city := range ds.Cities {
+		if city.Name == "" {
+			continue
+		}
+		base := city.Slug
+		if !SlugPattern.MatchString(base) {
+			base = Slugify(city.Name)
+		}
+		if base == "" {
+			base = "city"
+		}
+		e := entry{key: CityKey(city.DistrictSlug, city.Name), base: base, district: city.DistrictSlug}
+		if i, ok := index[e.key]; ok {
+			entries[i] = e
+			continue
+		}
+		index[e.key] = len(entries)
+		entries = append(entries, e)
+	}
+
+	slugs := make(map[string]string, len(entries))
+	taken := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if slug := existing[e.key]; slug != "" && !taken[slug] {
+			slugs[e.key] = slug
+			taken[slug] = true
+		}
+	}
+
+	shared := make(map[string]int)
+	for _, e := range entries {
+		if _, assigned := slugs[e.key]; !assigned {
+			shared[e.base]++
+		}
+	}
+	for _, e := range entries {
+		if _, assigned := slugs[e.key]; !assigned && shared[e.base] == 1 && !taken[e.base] {
+			slugs[e.key] = e.base
+			taken[e.base] = true
+		}
+	}
+	for _, e := range entries {
+		if _, assigned := slugs[e.key]; assigned {
+			continue
+		}
+		qualified := e.base + "-" + districtTypeSuffix.ReplaceAllString(e.district, "")
+		slug := qualified
+		for n := 2; taken[slug]; n++ {
+			slug = qualified + "-" + strconv.Itoa(n)
+		}
+		slugs[e.key] = slug
+		taken[slug] = true
+	}
+	return slugs
+}
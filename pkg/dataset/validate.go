@@ -0,0 +1,413 @@
+package dataset
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Ghana's bounding box, with a small margin for coastal and border towns.
+const (
+	MinLat = 4.5
+	MaxLat = 11.5
+	MinLng = -3.5
+	MaxLng = 1.5
+)
+
+// SlugPattern is the format every slug must follow: lower-case words of
+// letters and digits joined by single hyphens.
+var SlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+var ecLetterPattern = regexp.MustCompile(`^[A-Z]$`)
+
+// Issue is a consistency problem found in the data files. A warning is a
+// record that is left out when the data is loaded, such as a city without a
+// name, rather than one that breaks the integrity of the dataset.
+type Issue struct {
+	File    string
+	Record  string
+	Message string
+	Warning bool
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.File, i.Record, i.Message)
+}
+
+// Validate checks the referential consistency of the dataset: references to
+// unknown regions and districts, duplicate and malformed slugs, coordinates
+// outside Ghana, cities listed twice in a district, malformed validity dates,
+// missing or repeated EC region letters and constituency numbers,
+// constituencies whose region in region-constituencies.json disagrees with
+// their district's region, lineage events that are malformed or name
+// unknown records, and alternate names that are malformed, name unknown
+// records, repeat another alternate name or shadow a slug. Cities without a
+// name are reported as warnings, as they are skipped when the data is loaded.
+func (ds *Dataset) Validate() []Issue {
+	var issues []Issue
+	report := func(file, record, format string, args ...any) {
+		issues = append(issues, Issue{File: file, Record: record, Message: fmt.Sprintf(format, args...)})
+	}
+
+	countries := make(map[string]bool)
+	for _, country := range ds.Countries {
+		if countries[country.Code] {
+			report("countries.json", country.Code, "duplicate code")
+		}
+		countries[country.Code] = true
+	}
+
+	regions := make(map[string]Region)
+	regionLetters := make(map[string]string)
+	for _, region := range ds.Regions {
+		checkSlug(region.Slug, "regions.json", report)
+		if _, exists := regions[region.Slug]; exists {
+			report("regions.json", region.Slug, "duplicate slug")
+		}
+		regions[region.Slug] = region
+		checkValidity(region.Validity, region.Slug, "regions.json", report)
+		if region.ECLetter == "" && region.Retired() {
+			continue
+		}
+		if !ecLetterPattern.MatchString(region.ECLetter) {
+			report("regions.json", region.Slug, "ec_letter %q is not a single upper-case letter", region.ECLetter)
+		} else if other, exists := regionLetters[region.ECLetter]; exists {
+			report("regions.json", region.Slug, "ec_letter %q is also used by %q", region.ECLetter, other)
+		}
+		regionLetters[region.ECLetter] = region.Slug
+	}
+
+	districts := make(map[string]District)
+	for _, district := range ds.Districts {
+		checkSlug(district.Slug, "districts.json", report)
+		if _, exists := districts[district.Slug]; exists {
+			report("districts.json", district.Slug, "duplicate slug")
+		}
+		if _, exists := regions[district.RegionSlug]; !exists {
+			report("districts.json", district.Slug, "unknown region_slug %q", district.RegionSlug)
+		}
+		districts[district.Slug] = district
+		checkValidity(district.Validity, district.Slug, "districts.json", report)
+	}
+
+	constituencies := make(map[string]bool)
+	// Constituencies split from one another share their ec_number, so
+	// numbers are not unique within a region.
+	for _, constituency := range ds.Constituencies {
+		if constituency.ECNumber != nil {
+			if number := *constituency.ECNumber; number < 1 || number > 99 {
+				report("constituencies.json", constituency.Slug, "ec_number %d is not between 1 and 99", number)
+			}
+		}
+
+		checkSlug(constituency.Slug, "constituencies.json", report)
+		if constituencies[constituency.Slug] {
+			report("constituencies.json", constituency.Slug, "duplicate slug")
+		}
+		constituencies[constituency.Slug] = true
+		checkValidity(constituency.Validity, constituency.Slug, "constituencies.json", report)
+		if _, exists := regions[constituency.RegionSlug]; !exists {
+			report("constituencies.json", constituency.Slug, "unknown region_slug %q", constituency.RegionSlug)
+		}
+		if constituency.DistrictSlug == nil {
+			continue
+		}
+		district, exists := districts[*constituency.DistrictSlug]
+		if !exists {
+			report("constituencies.json", constituency.Slug, "unknown district_slug %q", *constituency.DistrictSlug)
+			continue
+		}
+		if district.RegionSlug != constituency.RegionSlug {
+			report("constituencies.json", constituency.Slug, "region_slug %q but district %q is in %q", constituency.RegionSlug, district.Slug, district.RegionSlug)
+		}
+	}
+
+	cities := make(map[string]bool)
+	for _, city := range ds.Cities {
+		record := CityKey(city.DistrictSlug, city.Name)
+		if city.Name == "" {
+			issues = append(issues, Issue{File: "cities.json", Record: record, Message: "city has no name, skipped", Warning: true})
+		}
+		if city.Slug != "" {
+			checkSlug(city.Slug, "cities.json", report)
+		}
+		if _, exists := districts[city.DistrictSlug]; !exists {
+			report("cities.json", record, "unknown district_slug %q", city.DistrictSlug)
+		}
+		key := CityKey(city.DistrictSlug, normalizeECName(city.Name))
+		if cities[key] {
+			report("cities.json", record, "duplicate city in district")
+		}
+		cities[key] = true
+		if (city.Lat == nil) != (city.Lng == nil) {
+			report("cities.json", record, "has only one of lat and lng")
+		} else if city.Lat != nil && (*city.Lat < MinLat || *city.Lat > MaxLat || *city.Lng < MinLng || *city.Lng > MaxLng) {
+			report("cities.json", record, "coordinates %.6f, %.6f are outside Ghana", *city.Lat, *city.Lng)
+		}
+	}
+
+	issues = append(issues, ds.validateDistrictRegions(regions)...)
+	issues = append(issues, ds.validateRegionConstituencies(districts)...)
+	issues = append(issues, ds.validateLineage(districts, constituencies)...)
+	issues = append(issues, ds.validateAlternateNames(regions, districts, constituencies, ds.CitySlugs(nil))...)
+	issues = append(issues, ds.validateLocalizedNames(regions, districts, constituencies, ds.CitySlugs(nil))...)
+	return issues
+}
+
+// validateDistrictRegions checks that the former regions of each district are
+// known and in date order within the district's validity, and that every
+// region a district belonged to existed for the whole period it did, so a
+// district that predates its region lists the region it was in before.
+func (ds *Dataset) validateDistrictRegions(regions map[string]Region) []Issue {
+	var issues []Issue
+	report := func(district, format string, args ...any) {
+		issues = append(issues, Issue{File: "districts.json", Record: district, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, district := range ds.Districts {
+		for _, former := range district.FormerRegions {
+			if _, exists := regions[former.RegionSlug]; !exists {
+				report(district.Slug, "unknown former region %q", former.RegionSlug)
+			}
+		}
+	}
+
+	// Unknown regions are reported above or, for current regions, with the
+	// district.
+	for _, period := range ds.DistrictRegions() {
+		region, exists := regions[period.RegionSlug]
+		if !exists {
+			continue
+		}
+		from, to, err := period.Dates()
+		if err != nil {
+			report(period.DistrictSlug, "in region %q: %v", period.RegionSlug, err)
+			continue
+		}
+		regionFrom, regionTo, err := region.Dates()
+		if err != nil {
+			continue
+		}
+		if regionFrom != nil && (from == nil || from.Before(*regionFrom)) {
+			report(period.DistrictSlug, "in region %q %s, before it existed from %s", period.RegionSlug, periodStart(period.ValidFrom), *region.ValidFrom)
+		}
+		if regionTo != nil && (to == nil || to.After(*regionTo)) {
+			report(period.DistrictSlug, "in region %q after it was retired on %s", period.RegionSlug, *region.ValidTo)
+		}
+	}
+	return issues
+}
+
+// periodStart describes when a period starting on date began.
+func periodStart(date *string) string {
+	if date == nil {
+		return "since before records begin"
+	}
+	return "from " + *date
+}
+
+// validateRegionConstituencies compares region-constituencies.json with the
+// region each constituency's district belongs to.
+func (ds *Dataset) validateRegionConstituencies(districts map[string]District) []Issue {
+	var issues []Issue
+	const file = "region-constituencies.json"
+
+	listed, unknown := ds.listedConstituencyRegions()
+	for _, name := range unknown {
+		issues = append(issues, Issue{File: file, Record: name, Message: "unknown region"})
+	}
+
+	for _, constituency := range ds.Constituencies {
+		if constituency.DistrictSlug == nil {
+			continue
+		}
+		district, exists := districts[*constituency.DistrictSlug]
+		if !exists {
+			continue
+		}
+		listedRegions := listed[normalizeECName(constituency.Name)]
+		if len(listedRegions) == 0 {
+			continue
+		}
+		matches := false
+		for _, regionSlug := range listedRegions {
+			matches = matches || regionSlug == district.RegionSlug
+		}
+		if !matches {
+			issues = append(issues, Issue{
+				File:    file,
+				Record:  constituency.Slug,
+				Message: fmt.Sprintf("listed under %s but district %q is in %q", strings.Join(listedRegions, ", "), district.Slug, district.RegionSlug),
+			})
+		}
+	}
+
+	return issues
+}
+
+// validateLineage checks that each lineage event has a known type and kind,
+// a valid date, the number of records its type implies and only records
+// listed in the data files.
+func (ds *Dataset) validateLineage(districts map[string]District, constituencies map[string]bool) []Issue {
+	var issues []Issue
+	report := func(event LineageEvent, format string, args ...any) {
+		record := fmt.Sprintf("%s %s of %s", event.Kind, event.Type, strings.Join(event.From, ", "))
+		issues = append(issues, Issue{File: LineageFile, Record: record, Message: fmt.Sprintf(format, args...)})
+	}
+
+	known := map[string]func(slug string) bool{
+		"district":     func(slug string) bool { _, exists := districts[slug]; return exists },
+		"constituency": func(slug string) bool { return constituencies[slug] },
+	}
+	for _, event := range ds.Lineage {
+		exists, ok := known[event.Kind]
+		if !ok {
+			report(event, "kind %q is not one of %s", event.Kind, strings.Join(LineageKinds, ", "))
+			continue
+		}
+
+		switch event.Type {
+		case LineageSplit:
+			if len(event.From) != 1 || len(event.To) == 0 {
+				report(event, "a split has one record in from and at least one in to")
+			}
+		case LineageMerge:
+			if len(event.From) < 2 || len(event.To) != 1 {
+				report(event, "a merge has at least two records in from and one in to")
+			}
+		case LineageRename:
+			if len(event.From) != 1 || len(event.To) != 1 {
+				report(event, "a rename has one record in from and one in to")
+			}
+		default:
+			report(event, "type %q is not one of %s, %s, %s", event.Type, LineageSplit, LineageMerge, LineageRename)
+		}
+
+		if _, err := parseDate(&event.Date); err != nil {
+			report(event, "invalid date: %v", err)
+		}
+		for _, slug := range append(append([]string{}, event.From...), event.To...) {
+			if !exists(slug) {
+				report(event, "unknown %s %q", event.Kind, slug)
+			}
+		}
+		for _, from := range event.From {
+			for _, to := range event.To {
+				if from == to {
+					report(event, "%q is listed in both from and to", from)
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// validateAlternateNames checks that each alternate name has a known kind and
+// type, refers to a known record and has a key that no other alternate name
+// or slug of its kind has, as lookups would never reach it.
+func (ds *Dataset) validateAlternateNames(regions map[string]Region, districts map[string]District, constituencies map[string]bool, citySlugs map[string]string) []Issue {
+	var issues []Issue
+	report := func(name AlternateName, format string, args ...any) {
+		issues = append(issues, Issue{File: AlternateNamesFile, Record: name.Kind + "/" + name.Name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	cities := make(map[string]bool, len(citySlugs))
+	for _, slug := range citySlugs {
+		cities[slug] = true
+	}
+	slugs := map[string]func(slug string) bool{
+		"region":       func(slug string) bool { _, exists := regions[slug]; return exists },
+		"district":     func(slug string) bool { _, exists := districts[slug]; return exists },
+		"constituency": func(slug string) bool { return constituencies[slug] },
+		"city":         func(slug string) bool { return cities[slug] },
+	}
+
+	keys := make(map[string]string)
+	for _, name := range ds.AlternateNames {
+		exists, ok := slugs[name.Kind]
+		if !ok {
+			report(name, "unknown kind %q", name.Kind)
+			continue
+		}
+		if !slices.Contains(AlternateNameTypes, name.Type) {
+			report(name, "type %q is not one of %s", name.Type, strings.Join(AlternateNameTypes, ", "))
+		}
+		if !exists(name.Slug) {
+			report(name, "unknown %s %q", name.Kind, name.Slug)
+		}
+
+		key := Slugify(name.Name)
+		switch {
+		case key == "":
+			report(name, "name has no letters or digits")
+		case exists(key):
+			report(name, "name is the slug of %s %q", name.Kind, key)
+		case keys[name.Kind+"/"+key] != "":
+			report(name, "name is also an alternate name of %q", keys[name.Kind+"/"+key])
+		}
+		keys[name.Kind+"/"+key] = name.Slug
+	}
+	return issues
+}
+
+// validateLocalizedNames checks that each localized name has a known kind,
+// refers to a known record, has a valid language code other than English and
+// is the only name of its record in that language.
+func (ds *Dataset) validateLocalizedNames(regions map[string]Region, districts map[string]District, constituencies map[string]bool, citySlugs map[string]string) []Issue {
+	var issues []Issue
+	report := func(name LocalizedName, format string, args ...any) {
+		issues = append(issues, Issue{File: LocalizedNamesFile, Record: name.Kind + "/" + name.Slug + "/" + name.Lang, Message: fmt.Sprintf(format, args...)})
+	}
+
+	cities := make(map[string]bool, len(citySlugs))
+	for _, slug := range citySlugs {
+		cities[slug] = true
+	}
+	slugs := map[string]func(slug string) bool{
+		"region":       func(slug string) bool { _, exists := regions[slug]; return exists },
+		"district":     func(slug string) bool { _, exists := districts[slug]; return exists },
+		"constituency": func(slug string) bool { return constituencies[slug] },
+		"city":         func(slug string) bool { return cities[slug] },
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range ds.LocalizedNames {
+		exists, ok := slugs[name.Kind]
+		if !ok {
+			report(name, "unknown kind %q", name.Kind)
+			continue
+		}
+		if !exists(name.Slug) {
+			report(name, "unknown %s %q", name.Kind, name.Slug)
+		}
+		switch {
+		case !LanguagePattern.MatchString(name.Lang):
+			report(name, "lang is not a lower-case ISO 639 code")
+		case name.Lang == "en":
+			report(name, "lang en is the name in the record's own data file")
+		}
+		if strings.TrimSpace(name.Name) == "" {
+			report(name, "name is empty")
+		}
+
+		key := name.Kind + "/" + name.Slug + "/" + name.Lang
+		if seen[key] {
+			report(name, "duplicate name in language")
+		}
+		seen[key] = true
+	}
+	return issues
+}
+
+func checkValidity(validity Validity, record, file string, report func(file, record, format string, args ...any)) {
+	if _, _, err := validity.Dates(); err != nil {
+		report(file, record, "%v", err)
+	}
+}
+
+func checkSlug(slug, file string, report func(file, record, format string, args ...any)) {
+	if !SlugPattern.MatchString(slug) {
+		report(file, slug, "slug is not lower-case words joined by hyphens")
+	}
+}
@@ -0,0 +1,43 @@
+package dataset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// versionFiles are the data files whose contents make up the dataset version.
+var versionFiles = []string{
+	"countries.json",
+	"regions.json",
+	"districts.json",
+	"constituencies.json",
+	"cities.json",
+	"region-constituencies.json",
+	LineageFile,
+	AlternateNamesFile,
+	LocalizedNamesFile,
+	PollingStationsFile,
+}
+
+// Version identifies the contents of the data files at the root of fsys: it
+// changes whenever any of them does. It is a short hex digest, e.g.
+// "3f9a0c2d41b7e865".
+func Version(fsys fs.FS) (string, error) {
+	hash := sha256.New()
+	for _, name := range versionFiles {
+		file, err := fsys.Open(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		fmt.Fprintf(hash, "%s\x00", name)
+		_, err = io.Copy(hash, file)
+		file.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", name, err)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16], nil
+}
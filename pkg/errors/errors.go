@@ -1,17 +1,167 @@
-package errors
-
-import (
-	"errors"
-	"net/http"
-)
-
-var (
-	ErrNotFound    = errors.New("resource not found")
-	ErrInvalidSlug = errors.New("invalid slug format")
-)
-
-func WriteError(w http.ResponseWriter, statusCode int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	w.Write([]byte(`{"error":"` + message + `"}`))
-}
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+var (
+	ErrNotFound           = errors.New("resource not found")
+	ErrInvalidSlug        = errors.New("invalid slug format")
+	ErrInvalidCode        = errors.New("invalid polling station code format")
+	ErrInvalidQuery       = errors.New("invalid search query")
+	ErrInvalidCoordinates = errors.New("invalid coordinates")
+	ErrInvalidRadius      = errors.New("invalid radius")
+	ErrNoCoordinates      = errors.New("location has no coordinates")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+	ErrInvalidSort        = errors.New("invalid sort field")
+	ErrInvalidFilter      = errors.New("invalid filter")
+	ErrInvalidParameter   = errors.New("invalid parameter")
+	ErrHierarchyMismatch  = errors.New("location is not within its parent")
+	ErrMethodNotAllowed   = errors.New("method not allowed")
+	ErrAlias              = errors.New("slug is an alias")
+)
+
+// Codes identify the kind of error in an error response, so clients can
+// match on them instead of on the message.
+const (
+	CodeNotFound           = "not_found"
+	CodeInvalidSlug        = "invalid_slug"
+	CodeInvalidCode        = "invalid_code"
+	CodeInvalidQuery       = "invalid_query"
+	CodeInvalidCoordinates = "invalid_coordinates"
+	CodeInvalidRadius      = "invalid_radius"
+	CodeNoCoordinates      = "no_coordinates"
+	CodeInvalidCursor      = "invalid_cursor"
+	CodeInvalidSort        = "invalid_sort"
+	CodeInvalidFilter      = "invalid_filter"
+	CodeInvalidParameter   = "invalid_parameter"
+	CodeHierarchyMismatch  = "hierarchy_mismatch"
+	CodeMethodNotAllowed   = "method_not_allowed"
+	CodeAlias              = "alias"
+	CodeInternal           = "internal_error"
+)
+
+// kinds maps each sentinel error to the status and code it is reported with.
+var kinds = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{ErrNotFound, http.StatusNotFound, CodeNotFound},
+	{ErrInvalidSlug, http.StatusBadRequest, CodeInvalidSlug},
+	{ErrInvalidCode, http.StatusBadRequest, CodeInvalidCode},
+	{ErrInvalidQuery, http.StatusBadRequest, CodeInvalidQuery},
+	{ErrInvalidCoordinates, http.StatusBadRequest, CodeInvalidCoordinates},
+	{ErrInvalidRadius, http.StatusBadRequest, CodeInvalidRadius},
+	{ErrNoCoordinates, http.StatusUnprocessableEntity, CodeNoCoordinates},
+	{ErrInvalidCursor, http.StatusBadRequest, CodeInvalidCursor},
+	{ErrInvalidSort, http.StatusBadRequest, CodeInvalidSort},
+	{ErrInvalidFilter, http.StatusBadRequest, CodeInvalidFilter},
+	{ErrInvalidParameter, http.StatusBadRequest, CodeInvalidParameter},
+	{ErrHierarchyMismatch, http.StatusUnprocessableEntity, CodeHierarchyMismatch},
+	{ErrMethodNotAllowed, http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+	{ErrAlias, http.StatusMovedPermanently, CodeAlias},
+}
+
+// Kind returns the HTTP status and code of err, found by matching it against
+// the sentinel errors with errors.Is. Any other error is an internal error.
+func Kind(err error) (status int, code string) {
+	for _, kind := range kinds {
+		if errors.Is(err, kind.err) {
+			return kind.status, kind.code
+		}
+	}
+	return http.StatusInternalServerError, CodeInternal
+}
+
+// ForCode returns the sentinel error reported with code, or nil for
+// internal and unknown codes.
+func ForCode(code string) error {
+	for _, kind := range kinds {
+		if kind.code == code {
+			return kind.err
+		}
+	}
+	return nil
+}
+
+// ParameterError is a missing or malformed request parameter. It matches
+// ErrInvalidParameter with errors.Is.
+type ParameterError struct {
+	Parameter string
+	Message   string
+}
+
+// InvalidParameter returns a ParameterError for parameter.
+func InvalidParameter(parameter, message string) error {
+	return &ParameterError{Parameter: parameter, Message: message}
+}
+
+func (e *ParameterError) Error() string {
+	return e.Message
+}
+
+func (e *ParameterError) Unwrap() error {
+	return ErrInvalidParameter
+}
+
+// AliasError is returned for a lookup by a slug that names a record without
+// being its slug: a differently cased or punctuated slug, or an alternate
+// name such as "Brong Ahafo". Canonical is the record's slug. It matches
+// ErrAlias with errors.Is.
+type AliasError struct {
+	Slug      string
+	Canonical string
+}
+
+// Alias returns an AliasError for slug.
+func Alias(slug, canonical string) error {
+	return &AliasError{Slug: slug, Canonical: canonical}
+}
+
+func (e *AliasError) Error() string {
+	return fmt.Sprintf("%q is an alias of %q", e.Slug, e.Canonical)
+}
+
+func (e *AliasError) Unwrap() error {
+	return ErrAlias
+}
+
+// Response is the body of every error response.
+type Response struct {
+	Error Body `json:"error"`
+}
+
+type Body struct {
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	Details   map[string]any `json:"details,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+}
+
+// WriteError writes err as an error response with the status and code given
+// by Kind. message is the text shown to clients; the request ID set by chi's
+// RequestID middleware is included so a response can be matched to its log
+// line.
+func WriteError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	status, code := Kind(err)
+	body := Body{
+		Code:      code,
+		Message:   message,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+
+	var paramErr *ParameterError
+	if errors.As(err, &paramErr) {
+		body.Details = map[string]any{"parameter": paramErr.Parameter}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Response{Error: body})
+}
@@ -0,0 +1,36 @@
+package geo
+
+import "math"
+
+// EarthRadiusKm is the mean radius of the Earth.
+const EarthRadiusKm = 6371.0
+
+// KmPerDegreeLat is the length of one degree of latitude.
+const KmPerDegreeLat = 111.32
+
+// ValidPoint reports whether lat and lng are finite and within the ranges of
+// latitudes and longitudes. NaN compares false with every bound, so range
+// checks alone let it through.
+func ValidPoint(lat, lng float64) bool {
+	if math.IsNaN(lat) || math.IsNaN(lng) || math.IsInf(lat, 0) || math.IsInf(lng, 0) {
+		return false
+	}
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+// HaversineKm returns the great-circle distance in km between two points.
+func HaversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLng := (lng2 - lng1) * toRad
+	a := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Pow(math.Sin(dLng/2), 2)
+	return EarthRadiusKm * 2 * math.Asin(math.Sqrt(a))
+}
+
+// BoundingBox returns the latitude and longitude ranges that contain every
+// point within radiusKm of (lat, lng).
+func BoundingBox(lat, lng, radiusKm float64) (minLat, maxLat, minLng, maxLng float64) {
+	dLat := radiusKm / KmPerDegreeLat
+	dLng := radiusKm / (KmPerDegreeLat * math.Max(math.Cos(lat*math.Pi/180), 0.01))
+	return lat - dLat, lat + dLat, lng - dLng, lng + dLng
+}
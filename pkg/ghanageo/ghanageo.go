@@ -0,0 +1,425 @@
+// Package ghanageo answers questions about Ghana's administrative hierarchy
+// (regions, districts, constituencies, cities and polling stations) from the
+// dataset compiled into the binary, with no network or database access.
+//
+// Lookups go through the same LocationService and repositories the API uses
+// in embedded mode, so the library and the API always agree.
+package ghanageo
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+
+	"github.com/ghana-location-api/data"
+	"github.com/ghana-location-api/pkg/dataset"
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/repositories"
+	"github.com/ghana-location-api/pkg/repositories/memory"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+// Hierarchy is a loaded copy of the dataset. It is safe for concurrent use.
+type Hierarchy struct {
+	service *services.LocationService
+
+	regions []models.Region
+
+	// regionByID and districtByID hold every region and district, retired
+	// ones included, so the parents of retired records resolve.
+	regionByID   map[string]*models.Region
+	districtByID map[string]*models.District
+}
+
+// New loads the embedded dataset.
+func New() (*Hierarchy, error) {
+	store, err := memory.NewStore(data.FS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded dataset: %w", err)
+	}
+	h := &Hierarchy{
+		service:      memory.NewLocationService(store),
+		regionByID:   make(map[string]*models.Region),
+		districtByID: make(map[string]*models.District),
+	}
+
+	ctx := context.Background()
+	h.regions, err = collect(ctx, h.service.GetAllRegions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lists only hold what exists today, while lookups by slug also find
+	// retired records, so the indexes are built from every slug in the data.
+	ds, err := dataset.Load(data.FS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded dataset: %w", err)
+	}
+	for _, r := range ds.Regions {
+		region, err := h.service.GetRegionBySlug(ctx, r.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("region %q: %w", r.Slug, err)
+		}
+		h.regionByID[region.ID] = region
+	}
+	for _, d := range ds.Districts {
+		district, err := h.service.GetDistrictBySlug(ctx, d.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("district %q: %w", d.Slug, err)
+		}
+		h.districtByID[district.ID] = district
+	}
+
+	return h, nil
+}
+
+var loadDefault = sync.OnceValues(New)
+
+// Default returns a Hierarchy shared by the whole process, loading it on first use.
+func Default() (*Hierarchy, error) {
+	return loadDefault()
+}
+
+// Lookups by slug or code. They return errors.ErrNotFound when nothing
+// matches and errors.ErrInvalidSlug or errors.ErrInvalidCode for malformed input.
+// Slugs may also be given in other case or as alternate names, such as
+// "Brong Ahafo" or "KMA", which the API would redirect from.
+
+func (h *Hierarchy) Country(code string) (*models.Country, error) {
+	return h.service.GetCountryByCode(context.Background(), code)
+}
+
+func (h *Hierarchy) Region(slug string) (*models.Region, error) {
+	return followAlias(slug, h.service.GetRegionBySlug)
+}
+
+func (h *Hierarchy) District(slug string) (*models.District, error) {
+	return followAlias(slug, h.service.GetDistrictBySlug)
+}
+
+func (h *Hierarchy) Constituency(slug string) (*models.Constituency, error) {
+	return followAlias(slug, h.service.GetConstituencyBySlug)
+}
+
+func (h *Hierarchy) City(slug string) (*models.City, error) {
+	return followAlias(slug, h.service.GetCityBySlug)
+}
+
+func (h *Hierarchy) PollingStation(code string) (*models.PollingStation, error) {
+	return h.service.GetPollingStationByCode(context.Background(), code)
+}
+
+// DecodePollingStationCode splits a polling station code into its region
+// letter, constituency, electoral area and station numbers, with the region
+// and constituency they refer to. It works for codes of stations that are not
+// in the EC list too. For parsing alone, see pollingcode.Parse.
+func (h *Hierarchy) DecodePollingStationCode(code string) (*models.PollingStationCode, error) {
+	return h.service.DecodePollingStationCode(context.Background(), code)
+}
+
+// DistrictLineage returns the districts a district was split, merged or
+// renamed from and into.
+func (h *Hierarchy) DistrictLineage(slug string) (*models.Lineage, error) {
+	return followAlias(slug, h.service.GetDistrictLineage)
+}
+
+// DistrictSuccessors returns the current districts that replaced a retired
+// district, such as those created when it was split.
+func (h *Hierarchy) DistrictSuccessors(slug string) ([]models.LineageLink, error) {
+	district, err := h.District(slug)
+	if err != nil {
+		return nil, err
+	}
+	return h.service.GetDistrictSuccessors(context.Background(), district.Slug)
+}
+
+// ConstituencyLineage returns the constituencies a constituency was split,
+// merged or renamed from and into.
+func (h *Hierarchy) ConstituencyLineage(slug string) (*models.Lineage, error) {
+	return followAlias(slug, h.service.GetConstituencyLineage)
+}
+
+// Children, ordered by name (polling stations by code).
+
+// Regions returns every region.
+func (h *Hierarchy) Regions() []models.Region {
+	return append([]models.Region(nil), h.regions...)
+}
+
+// Districts returns the districts of a region.
+func (h *Hierarchy) Districts(regionSlug string) ([]models.District, error) {
+	region, err := h.Region(regionSlug)
+	if err != nil {
+		return nil, err
+	}
+	return collect(context.Background(), func(ctx context.Context, opts models.ListOptions) (*models.List[models.District], error) {
+		return h.service.GetDistrictsByRegionSlug(ctx, region.Slug, opts)
+	})
+}
+
+// Constituencies returns the constituencies of a district.
+func (h *Hierarchy) Constituencies(districtSlug string) ([]models.Constituency, error) {
+	district, err := h.District(districtSlug)
+	if err != nil {
+		return nil, err
+	}
+	return collect(context.Background(), func(ctx context.Context, opts models.ListOptions) (*models.List[models.Constituency], error) {
+		return h.service.GetConstituenciesByDistrictSlug(ctx, district.Slug, opts)
+	})
+}
+
+// ConstituenciesInRegion returns the constituencies of a region, including
+// those whose district is not recorded.
+func (h *Hierarchy) ConstituenciesInRegion(regionSlug string) ([]models.Constituency, error) {
+	region, err := h.Region(regionSlug)
+	if err != nil {
+		return nil, err
+	}
+	return collect(context.Background(), func(ctx context.Context, opts models.ListOptions) (*models.List[models.Constituency], error) {
+		return h.service.GetConstituenciesByRegionSlug(ctx, region.Slug, opts)
+	})
+}
+
+// Cities returns the cities and towns of a district.
+func (h *Hierarchy) Cities(districtSlug string) ([]models.City, error) {
+	district, err := h.District(districtSlug)
+	if err != nil {
+		return nil, err
+	}
+	return collect(context.Background(), func(ctx context.Context, opts models.ListOptions) (*models.List[models.City], error) {
+		return h.service.GetCitiesByDistrictSlug(ctx, district.Slug, opts)
+	})
+}
+
+// PollingStations returns the polling stations of a constituency.
+func (h *Hierarchy) PollingStations(constituencySlug string) ([]models.PollingStation, error) {
+	constituency, err := h.Constituency(constituencySlug)
+	if err != nil {
+		return nil, err
+	}
+	return collect(context.Background(), func(ctx context.Context, opts models.ListOptions) (*models.List[models.PollingStation], error) {
+		return h.service.GetPollingStationsByConstituencySlug(ctx, constituency.Slug, opts)
+	})
+}
+
+// Parents.
+
+// RegionOf returns the region a district belongs to.
+func (h *Hierarchy) RegionOf(districtSlug string) (*models.Region, error) {
+	district, err := h.District(districtSlug)
+	if err != nil {
+		return nil, err
+	}
+	return h.regionByID[district.RegionID], nil
+}
+
+// DistrictOf returns the district a constituency belongs to, or
+// errors.ErrNotFound when the dataset does not record one.
+func (h *Hierarchy) DistrictOf(constituencySlug string) (*models.District, error) {
+	constituency, err := h.Constituency(constituencySlug)
+	if err != nil {
+		return nil, err
+	}
+	if constituency.DistrictID == nil {
+		return nil, errors.ErrNotFound
+	}
+	return h.districtByID[*constituency.DistrictID], nil
+}
+
+// Match finds locations whose names match name, ignoring case and accents and
+// tolerating misspellings, best match first. types restricts the result types
+// (region, district, constituency, city).
+func (h *Hierarchy) Match(name string, types ...string) ([]models.SearchResult, error) {
+	return h.service.Search(context.Background(), name, types, 0)
+}
+
+// Names in other languages.
+
+// Languages returns the languages names can be shown in, English first.
+func (h *Hierarchy) Languages() ([]string, error) {
+	return h.service.Languages(context.Background())
+}
+
+// LocalizedName returns the name in lang of the region, district,
+// constituency or city with slug, as kind says, or its English name when it
+// has none in lang.
+func (h *Hierarchy) LocalizedName(kind, slug, lang string) (string, error) {
+	var name string
+	switch kind {
+	case "region":
+		region, err := h.Region(slug)
+		if err != nil {
+			return "", err
+		}
+		name, slug = region.Name, region.Slug
+	case "district":
+		district, err := h.District(slug)
+		if err != nil {
+			return "", err
+		}
+		name, slug = district.Name, district.Slug
+	case "constituency":
+		constituency, err := h.Constituency(slug)
+		if err != nil {
+			return "", err
+		}
+		name, slug = constituency.Name, constituency.Slug
+	case "city":
+		city, err := h.City(slug)
+		if err != nil {
+			return "", err
+		}
+		name, slug = city.Name, city.Slug
+	default:
+		return "", fmt.Errorf("unknown kind %q", kind)
+	}
+
+	names, err := h.service.LocalizedNames(context.Background(), lang)
+	if err != nil {
+		return "", err
+	}
+	if localized, ok := names[models.NameKey{Kind: kind, Slug: slug}]; ok {
+		return localized, nil
+	}
+	return name, nil
+}
+
+// Distances.
+
+// Nearby returns the cities within radiusKm of a city, nearest first. Zero
+// radiusKm or limit selects the default (10 km, 20 cities).
+func (h *Hierarchy) Nearby(citySlug string, radiusKm float64, limit int) ([]models.NearestCity, error) {
+	city, err := h.City(citySlug)
+	if err != nil {
+		return nil, err
+	}
+	nearby, err := h.service.GetNearbyCities(context.Background(), city.Slug, radiusKm, limit)
+	if err != nil {
+		return nil, err
+	}
+	return nearby.Cities, nil
+}
+
+// Distance returns the great-circle distance in km between two cities.
+func (h *Hierarchy) Distance(fromSlug, toSlug string) (float64, error) {
+	from, err := h.City(fromSlug)
+	if err != nil {
+		return 0, err
+	}
+	to, err := h.City(toSlug)
+	if err != nil {
+		return 0, err
+	}
+	distance, err := h.service.GetCityDistance(context.Background(), from.Slug, to.Slug)
+	if err != nil {
+		return 0, err
+	}
+	return distance.DistanceKm, nil
+}
+
+// Validation.
+
+// Location names a position in the hierarchy. Empty slugs are not checked,
+// but at least one must be set.
+type Location struct {
+	RegionSlug       string
+	DistrictSlug     string
+	ConstituencySlug string
+}
+
+// Validate checks that every slug in loc exists and that each level lies
+// within the one above it. It returns errors.ErrNotFound or
+// errors.ErrInvalidSlug for an unknown or malformed slug,
+// errors.ErrHierarchyMismatch when the levels do not nest, and
+// errors.ErrInvalidParameter when loc names no location at all.
+func (h *Hierarchy) Validate(loc Location) error {
+	if loc == (Location{}) {
+		return errors.InvalidParameter("location", "location has no region, district or constituency")
+	}
+
+	var region *models.Region
+	if loc.RegionSlug != "" {
+		var err error
+		if region, err = h.Region(loc.RegionSlug); err != nil {
+			return fmt.Errorf("region %q: %w", loc.RegionSlug, err)
+		}
+	}
+
+	var district *models.District
+	if loc.DistrictSlug != "" {
+		var err error
+		if district, err = h.District(loc.DistrictSlug); err != nil {
+			return fmt.Errorf("district %q: %w", loc.DistrictSlug, err)
+		}
+		if region != nil && district.RegionID != region.ID {
+			return fmt.Errorf("district %q is not in region %q: %w", loc.DistrictSlug, loc.RegionSlug, errors.ErrHierarchyMismatch)
+		}
+	}
+
+	if loc.ConstituencySlug != "" {
+		constituency, err := h.Constituency(loc.ConstituencySlug)
+		if err != nil {
+			return fmt.Errorf("constituency %q: %w", loc.ConstituencySlug, err)
+		}
+		var parent *models.District
+		if constituency.DistrictID != nil {
+			parent = h.districtByID[*constituency.DistrictID]
+		}
+		if district != nil && (parent == nil || parent.ID != district.ID) {
+			return fmt.Errorf("constituency %q is not in district %q: %w", loc.ConstituencySlug, loc.DistrictSlug, errors.ErrHierarchyMismatch)
+		}
+		if region != nil && (constituency.RegionID == nil || *constituency.RegionID != region.ID) {
+			return fmt.Errorf("constituency %q is not in region %q: %w", loc.ConstituencySlug, loc.RegionSlug, errors.ErrHierarchyMismatch)
+		}
+	}
+
+	return nil
+}
+
+// IsDistrictInRegion reports whether the district lies within the region.
+func (h *Hierarchy) IsDistrictInRegion(districtSlug, regionSlug string) (bool, error) {
+	return isWithin(h.Validate(Location{RegionSlug: regionSlug, DistrictSlug: districtSlug}))
+}
+
+// IsConstituencyInDistrict reports whether the constituency lies within the district.
+func (h *Hierarchy) IsConstituencyInDistrict(constituencySlug, districtSlug string) (bool, error) {
+	return isWithin(h.Validate(Location{DistrictSlug: districtSlug, ConstituencySlug: constituencySlug}))
+}
+
+func isWithin(err error) (bool, error) {
+	if stderrors.Is(err, errors.ErrHierarchyMismatch) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// followAlias repeats a lookup by an alias slug with the canonical slug, as
+// the library has no redirect to follow.
+func followAlias[T any](slug string, get func(context.Context, string) (T, error)) (T, error) {
+	ctx := context.Background()
+	result, err := get(ctx, slug)
+	var alias *errors.AliasError
+	if stderrors.As(err, &alias) {
+		return get(ctx, alias.Canonical)
+	}
+	return result, err
+}
+
+// collect reads every page of a list.
+func collect[T any](ctx context.Context, list func(context.Context, models.ListOptions) (*models.List[T], error)) ([]T, error) {
+	items := []T{}
+	opts := models.ListOptions{Limit: repositories.MaxListLimit}
+	for {
+		page, err := list(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Data...)
+		if page.NextCursor == nil {
+			return items, nil
+		}
+		opts.Cursor = *page.NextCursor
+	}
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+// AsOf reads the as_of query parameter, a YYYY-MM-DD date, and queries the
+// hierarchy as it stood on that date: lists only include the regions,
+// districts and constituencies valid on it, and lookups of records that
+// were not return 404. Without as_of, lists show the current hierarchy and
+// retired records still resolve by slug.
+func AsOf(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.URL.Query().Get("as_of")
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		date, err := models.ParseDate(value)
+		if err != nil {
+			writeParameterError(w, r, "as_of", "as_of must be a date in the form YYYY-MM-DD")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(models.WithAsOf(r.Context(), date)))
+	})
+}
@@ -1,40 +1,164 @@
-package handlers
-
-import (
-	"encoding/json"
-	"net/http"
-
-	"github.com/ghana-location-api/pkg/errors"
-	"github.com/ghana-location-api/pkg/services"
-)
-
-type CityHandler struct {
-	service *services.LocationService
-}
-
-func NewCityHandler(service *services.LocationService) *CityHandler {
-	return &CityHandler{service: service}
-}
-
-func (h *CityHandler) GetByDistrict(w http.ResponseWriter, r *http.Request) {
-	districtSlug := r.URL.Query().Get("district")
-	if districtSlug == "" {
-		errors.WriteError(w, http.StatusBadRequest, "district parameter is required")
-		return
-	}
-
-	cities, err := h.service.GetCitiesByDistrictSlug(r.Context(), districtSlug)
-	if err != nil {
-		if err == errors.ErrInvalidSlug {
-			errors.WriteError(w, http.StatusBadRequest, "invalid slug format")
-			return
-		}
-		errors.WriteError(w, http.StatusInternalServerError, "failed to fetch cities")
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "public, max-age=3600")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(cities)
-}
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+type CityHandler struct {
+	service *services.LocationService
+}
+
+func NewCityHandler(service *services.LocationService) *CityHandler {
+	return &CityHandler{service: service}
+}
+
+func (h *CityHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filter := models.CityFilter{
+		RegionSlug:   r.URL.Query().Get("region"),
+		DistrictSlug: r.URL.Query().Get("district"),
+	}
+	if hasCoordinates := r.URL.Query().Get("has_coordinates"); hasCoordinates != "" {
+		value, err := strconv.ParseBool(hasCoordinates)
+		if err != nil {
+			writeParameterError(w, r, "has_coordinates", "invalid has_coordinates parameter")
+			return
+		}
+		filter.HasCoordinates = &value
+	}
+
+	opts, fields, err := parseListParams[models.City](r)
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	cities, err := h.service.ListCities(r.Context(), filter, opts)
+	if err != nil {
+		writeError(w, r, err, "cities")
+		return
+	}
+
+	writeList(w, r, cities, fields)
+}
+
+func (h *CityHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "city slug is required")
+		return
+	}
+
+	city, err := h.service.GetCityDetail(r.Context(), slug)
+	if err != nil {
+		writeError(w, r, err, "city")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, city))
+}
+
+func (h *CityHandler) Nearby(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "city slug is required")
+		return
+	}
+
+	radiusKm := 0.0
+	if radiusParam := r.URL.Query().Get("radius_km"); radiusParam != "" {
+		var err error
+		radiusKm, err = strconv.ParseFloat(radiusParam, 64)
+		if err != nil {
+			writeParameterError(w, r, "radius_km", "invalid radius_km parameter")
+			return
+		}
+	}
+
+	limit := 0
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			writeParameterError(w, r, "limit", "invalid limit parameter")
+			return
+		}
+	}
+
+	nearby, err := h.service.GetNearbyCities(r.Context(), slug, radiusKm, limit)
+	if err != nil {
+		writeError(w, r, err, "city")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, nearby))
+}
+
+func (h *CityHandler) Distance(w http.ResponseWriter, r *http.Request) {
+	from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	if from == "" {
+		writeParameterError(w, r, "from", "from parameter is required")
+		return
+	}
+	if to == "" {
+		writeParameterError(w, r, "to", "to parameter is required")
+		return
+	}
+
+	distance, err := h.service.GetCityDistance(r.Context(), from, to)
+	if err != nil {
+		writeError(w, r, err, "city")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, distance))
+}
+
+func (h *CityHandler) Reverse(w http.ResponseWriter, r *http.Request) {
+	lat, errLat := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	lng, errLng := strconv.ParseFloat(r.URL.Query().Get("lng"), 64)
+	if errLat != nil {
+		writeParameterError(w, r, "lat", "lat and lng parameters are required")
+		return
+	}
+	if errLng != nil {
+		writeParameterError(w, r, "lng", "lat and lng parameters are required")
+		return
+	}
+
+	limit := 0
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			writeParameterError(w, r, "limit", "invalid limit parameter")
+			return
+		}
+	}
+
+	result, err := h.service.ReverseGeocode(r.Context(), lat, lng, limit)
+	if err != nil {
+		writeError(w, r, err, "nearest cities")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, result))
+}
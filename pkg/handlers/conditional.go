@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+// Cache-Control values of successful responses. The metadata names the
+// latest seed, so it is kept for less time than the data.
+const (
+	dataCacheControl = "public, max-age=3600"
+	metaCacheControl = "public, max-age=60"
+)
+
+// ConditionalGet adds ETag and Last-Modified headers to successful GET
+// responses and answers conditional requests with 304 Not Modified. Every
+// response is derived from the dataset, so the ETag is a digest of the
+// dataset revision, which changes with every seed, and the request URL, and
+// the last modification is the seed time, when known. Until a dataset version is recorded responses are
+// served in full without validators. cacheControl is the Cache-Control the
+// handlers send with 200 OK, which a 304 Not Modified repeats.
+func ConditionalGet(service *services.LocationService, cacheControl string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			version, err := service.DatasetVersion(r.Context())
+			if err != nil || version == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			vw := &validatorWriter{
+				ResponseWriter: w,
+				etag:           datasetETag(version.Revision(), r),
+				lastModified:   version.SeededAt.UTC().Truncate(time.Second),
+				matchAny:       matchesAny(r),
+			}
+			if notModified(r, vw.etag, vw.lastModified) {
+				vw.setValidators()
+				w.Header().Set("Cache-Control", cacheControl)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			next.ServeHTTP(vw, r)
+		})
+	}
+}
+
+// datasetETag returns a strong ETag for the response to r under the given
+// dataset revision. Without as_of the response shows the hierarchy as of
+// today, so the date is part of the digest, and without lang the language
+// comes from Accept-Language, so the language negotiated is too.
+func datasetETag(revision string, r *http.Request) string {
+	date := models.ValidityDate(r.Context()).String()
+	lang := models.Language(r.Context())
+	sum := sha256.Sum256([]byte(revision + "\x00" + date + "\x00" + lang + "\x00" + r.URL.RequestURI()))
+	return `"` + hex.EncodeToString(sum[:12]) + `"`
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since when the request
+// has no If-None-Match, as RFC 9110 orders them. An If-None-Match of * only
+// matches when the resource exists, which is not known until the handler has
+// run, so validatorWriter evaluates it.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if header := r.Header.Get("If-Modified-Since"); header != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(header)
+		return err == nil && !lastModified.After(since)
+	}
+	return false
+}
+
+// matchesAny reports whether the If-None-Match header of r is *.
+func matchesAny(r *http.Request) bool {
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		if strings.TrimSpace(candidate) == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// validatorWriter sets the ETag and Last-Modified headers when the handler
+// responds 200 OK, so error responses are never cached against them. With
+// matchAny set, a 200 OK is turned into a 304 Not Modified and its body is
+// dropped, while errors such as a 404 are passed through.
+type validatorWriter struct {
+	http.ResponseWriter
+	etag         string
+	lastModified time.Time
+	matchAny     bool
+	wroteHeader  bool
+	discard      bool
+}
+
+func (w *validatorWriter) setValidators() {
+	w.Header().Set("ETag", w.etag)
+	if !w.lastModified.IsZero() {
+		w.Header().Set("Last-Modified", w.lastModified.Format(http.TimeFormat))
+	}
+}
+
+func (w *validatorWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if status == http.StatusOK {
+			w.setValidators()
+			if w.matchAny {
+				w.discard = true
+				w.Header().Del("Content-Length")
+				status = http.StatusNotModified
+			}
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *validatorWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.discard {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *validatorWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ghana-location-api/data"
+	"github.com/ghana-location-api/pkg/handlers"
+	"github.com/ghana-location-api/pkg/repositories/memory"
+)
+
+// newRouter returns the API router over the embedded dataset.
+func newRouter(t *testing.T) http.Handler {
+	t.Helper()
+	store, err := memory.NewStore(data.FS)
+	if err != nil {
+		t.Fatalf("failed to load dataset: %v", err)
+	}
+	r := chi.NewRouter()
+	r.NotFound(handlers.NotFound)
+	r.MethodNotAllowed(handlers.MethodNotAllowed)
+	r.Route("/api/v1", handlers.Routes(memory.NewLocationService(store)))
+	return r
+}
+
+func serve(router http.Handler, method, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestConditionalGet(t *testing.T) {
+	router := newRouter(t)
+
+	tests := []struct {
+		target       string
+		cacheControl string
+	}{
+		{"/api/v1/regions/ashanti-region", "public, max-age=3600"},
+		{"/api/v1/regions", "public, max-age=3600"},
+		{"/api/v1/meta", "public, max-age=60"},
+	}
+	for _, tt := range tests {
+		for _, method := range []string{http.MethodGet, http.MethodHead} {
+			rec := serve(router, method, tt.target, nil)
+			etag := rec.Header().Get("ETag")
+			if rec.Code != http.StatusOK || etag == "" {
+				t.Errorf("%s %s = %d with ETag %q, want 200 with an ETag", method, tt.target, rec.Code, etag)
+				continue
+			}
+
+			rec = serve(router, method, tt.target, http.Header{"If-None-Match": {etag}})
+			if rec.Code != http.StatusNotModified {
+				t.Errorf("conditional %s %s = %d, want 304", method, tt.target, rec.Code)
+			}
+			if got := rec.Header().Get("ETag"); got != etag {
+				t.Errorf("conditional %s %s ETag = %q, want %q", method, tt.target, got, etag)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != tt.cacheControl {
+				t.Errorf("conditional %s %s Cache-Control = %q, want %q", method, tt.target, got, tt.cacheControl)
+			}
+			if method == http.MethodGet && rec.Body.Len() != 0 {
+				t.Errorf("conditional GET %s wrote a %d byte body", tt.target, rec.Body.Len())
+			}
+		}
+	}
+}
+
+func TestConditionalGetMatchAny(t *testing.T) {
+	router := newRouter(t)
+	header := http.Header{"If-None-Match": {"*"}}
+
+	rec := serve(router, http.MethodGet, "/api/v1/regions/ashanti-region", header)
+	if rec.Code != http.StatusNotModified || rec.Header().Get("Cache-Control") == "" || rec.Body.Len() != 0 {
+		t.Errorf("If-None-Match * on an existing region = %d, Cache-Control %q, %d byte body", rec.Code, rec.Header().Get("Cache-Control"), rec.Body.Len())
+	}
+
+	rec = serve(router, http.MethodGet, "/api/v1/regions/atlantis", header)
+	if rec.Code != http.StatusNotFound || rec.Header().Get("ETag") != "" {
+		t.Errorf("If-None-Match * on a missing region = %d with ETag %q, want 404 without", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	rec := serve(newRouter(t), http.MethodPost, "/api/v1/regions", nil)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /api/v1/regions = %d, want 405", rec.Code)
+	}
+}
@@ -1,45 +1,100 @@
-package handlers
-
-import (
-	"encoding/json"
-	"net/http"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/ghana-location-api/pkg/errors"
-	"github.com/ghana-location-api/pkg/services"
-)
-
-type ConstituencyHandler struct {
-	service *services.LocationService
-}
-
-func NewConstituencyHandler(service *services.LocationService) *ConstituencyHandler {
-	return &ConstituencyHandler{service: service}
-}
-
-func (h *ConstituencyHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
-	slug := chi.URLParam(r, "slug")
-	if slug == "" {
-		errors.WriteError(w, http.StatusBadRequest, "constituency slug is required")
-		return
-	}
-
-	constituency, err := h.service.GetConstituencyBySlug(r.Context(), slug)
-	if err != nil {
-		if err == errors.ErrNotFound {
-			errors.WriteError(w, http.StatusNotFound, "constituency not found")
-			return
-		}
-		if err == errors.ErrInvalidSlug {
-			errors.WriteError(w, http.StatusBadRequest, "invalid slug format")
-			return
-		}
-		errors.WriteError(w, http.StatusInternalServerError, "failed to fetch constituency")
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "public, max-age=3600")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(constituency)
-}
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+type ConstituencyHandler struct {
+	service *services.LocationService
+}
+
+func NewConstituencyHandler(service *services.LocationService) *ConstituencyHandler {
+	return &ConstituencyHandler{service: service}
+}
+
+func (h *ConstituencyHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filter := models.ConstituencyFilter{
+		RegionSlug:   r.URL.Query().Get("region"),
+		DistrictSlug: r.URL.Query().Get("district"),
+	}
+
+	opts, fields, err := parseListParams[models.Constituency](r)
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	constituencies, err := h.service.ListConstituencies(r.Context(), filter, opts)
+	if err != nil {
+		writeError(w, r, err, "constituencies")
+		return
+	}
+
+	writeList(w, r, constituencies, fields)
+}
+
+func (h *ConstituencyHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "constituency slug is required")
+		return
+	}
+
+	constituency, err := h.service.GetConstituencyDetail(r.Context(), slug)
+	if err != nil {
+		writeError(w, r, err, "constituency")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, constituency))
+}
+
+func (h *ConstituencyHandler) GetPollingStations(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "constituency slug is required")
+		return
+	}
+
+	opts, fields, err := parseListParams[models.PollingStation](r)
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	stations, err := h.service.GetPollingStationsByConstituencySlug(r.Context(), slug, opts)
+	if err != nil {
+		writeError(w, r, err, "polling stations")
+		return
+	}
+
+	writeList(w, r, stations, fields)
+}
+
+func (h *ConstituencyHandler) GetLineage(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "constituency slug is required")
+		return
+	}
+
+	lineage, err := h.service.GetConstituencyLineage(r.Context(), slug)
+	if err != nil {
+		writeError(w, r, err, "constituency")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, lineage))
+}
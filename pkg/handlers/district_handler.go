@@ -1,68 +1,134 @@
-package handlers
-
-import (
-	"encoding/json"
-	"net/http"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/ghana-location-api/pkg/errors"
-	"github.com/ghana-location-api/pkg/services"
-)
-
-type DistrictHandler struct {
-	service *services.LocationService
-}
-
-func NewDistrictHandler(service *services.LocationService) *DistrictHandler {
-	return &DistrictHandler{service: service}
-}
-
-func (h *DistrictHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
-	slug := chi.URLParam(r, "slug")
-	if slug == "" {
-		errors.WriteError(w, http.StatusBadRequest, "district slug is required")
-		return
-	}
-
-	district, err := h.service.GetDistrictBySlug(r.Context(), slug)
-	if err != nil {
-		if err == errors.ErrNotFound {
-			errors.WriteError(w, http.StatusNotFound, "district not found")
-			return
-		}
-		if err == errors.ErrInvalidSlug {
-			errors.WriteError(w, http.StatusBadRequest, "invalid slug format")
-			return
-		}
-		errors.WriteError(w, http.StatusInternalServerError, "failed to fetch district")
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "public, max-age=3600")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(district)
-}
-
-func (h *DistrictHandler) GetConstituencies(w http.ResponseWriter, r *http.Request) {
-	slug := chi.URLParam(r, "slug")
-	if slug == "" {
-		errors.WriteError(w, http.StatusBadRequest, "district slug is required")
-		return
-	}
-
-	constituencies, err := h.service.GetConstituenciesByDistrictSlug(r.Context(), slug)
-	if err != nil {
-		if err == errors.ErrInvalidSlug {
-			errors.WriteError(w, http.StatusBadRequest, "invalid slug format")
-			return
-		}
-		errors.WriteError(w, http.StatusInternalServerError, "failed to fetch constituencies")
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "public, max-age=3600")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(constituencies)
-}
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+type DistrictHandler struct {
+	service *services.LocationService
+}
+
+func NewDistrictHandler(service *services.LocationService) *DistrictHandler {
+	return &DistrictHandler{service: service}
+}
+
+func (h *DistrictHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filter := models.DistrictFilter{
+		RegionSlug: r.URL.Query().Get("region"),
+		Type:       r.URL.Query().Get("type"),
+	}
+
+	opts, fields, err := parseListParams[models.District](r)
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	districts, err := h.service.ListDistricts(r.Context(), filter, opts)
+	if err != nil {
+		writeError(w, r, err, "districts")
+		return
+	}
+
+	writeList(w, r, districts, fields)
+}
+
+func (h *DistrictHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "district slug is required")
+		return
+	}
+
+	expand, err := parseExpand(r, "constituencies", "cities")
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	district, err := h.service.GetDistrictTree(r.Context(), slug, expand)
+	if err != nil {
+		writeError(w, r, err, "district")
+		return
+	}
+
+	// Point clients holding a retired slug to the districts that replaced it.
+	if district.ValidTo != nil && !district.ValidOn(models.ValidityDate(r.Context())) {
+		district.Successors, err = h.service.GetDistrictSuccessors(r.Context(), district.Slug)
+		if err != nil {
+			writeError(w, r, err, "district")
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, district))
+}
+
+func (h *DistrictHandler) GetConstituencies(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "district slug is required")
+		return
+	}
+
+	opts, fields, err := parseListParams[models.Constituency](r)
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	constituencies, err := h.service.GetConstituenciesByDistrictSlug(r.Context(), slug, opts)
+	if err != nil {
+		writeError(w, r, err, "constituencies")
+		return
+	}
+
+	writeList(w, r, constituencies, fields)
+}
+
+func (h *DistrictHandler) GetBoundary(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "district slug is required")
+		return
+	}
+
+	boundary, err := h.service.GetDistrictBoundary(r.Context(), slug)
+	if err != nil {
+		writeError(w, r, err, "district boundary")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/geo+json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, boundary))
+}
+
+func (h *DistrictHandler) GetLineage(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "district slug is required")
+		return
+	}
+
+	lineage, err := h.service.GetDistrictLineage(r.Context(), slug)
+	if err != nil {
+		writeError(w, r, err, "district")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, lineage))
+}
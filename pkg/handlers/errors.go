@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ghana-location-api/pkg/errors"
+)
+
+// writeError responds with err. resource names what was requested, e.g.
+// "district boundary", and words the not-found and internal error messages;
+// other errors are shown with their own message. Internal errors are logged,
+// as their text is not shown to clients. Requests by an alias slug are
+// redirected to the canonical one.
+func writeError(w http.ResponseWriter, r *http.Request, err error, resource string) {
+	var alias *errors.AliasError
+	if stderrors.As(err, &alias) {
+		redirectAlias(w, r, alias)
+		return
+	}
+
+	message := err.Error()
+	switch status, _ := errors.Kind(err); status {
+	case http.StatusNotFound:
+		message = resource + " not found"
+	case http.StatusInternalServerError:
+		log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+		message = "failed to fetch " + resource
+	}
+	errors.WriteError(w, r, err, message)
+}
+
+// redirectAlias permanently redirects to the request URL with the alias slug
+// replaced by the canonical one: in the path when the route's {slug} holds
+// it, and otherwise in the query parameters that do, as for /distance.
+func redirectAlias(w http.ResponseWriter, r *http.Request, alias *errors.AliasError) {
+	target := *r.URL
+	rctx := chi.RouteContext(r.Context())
+	if rctx != nil && chi.URLParam(r, "slug") == alias.Slug {
+		target.Path = strings.Replace(rctx.RoutePattern(), "{slug}", alias.Canonical, 1)
+		target.RawPath = ""
+	} else {
+		query := target.Query()
+		for _, values := range query {
+			for i, value := range values {
+				if value == alias.Slug {
+					values[i] = alias.Canonical
+				}
+			}
+		}
+		target.RawQuery = query.Encode()
+	}
+	http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+}
+
+// writeParameterError responds that a request parameter is missing or malformed.
+func writeParameterError(w http.ResponseWriter, r *http.Request, parameter, message string) {
+	errors.WriteError(w, r, errors.InvalidParameter(parameter, message), message)
+}
+
+// NotFound responds to requests for unknown routes.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	errors.WriteError(w, r, errors.ErrNotFound, "route not found")
+}
+
+// MethodNotAllowed responds to requests with a method the route does not serve.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	errors.WriteError(w, r, errors.ErrMethodNotAllowed, "method not allowed")
+}
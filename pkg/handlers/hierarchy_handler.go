@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+type HierarchyHandler struct {
+	service *services.LocationService
+}
+
+func NewHierarchyHandler(service *services.LocationService) *HierarchyHandler {
+	return &HierarchyHandler{service: service}
+}
+
+// Get returns the whole country tree: regions, districts and constituencies,
+// plus cities when expand=cities.
+func (h *HierarchyHandler) Get(w http.ResponseWriter, r *http.Request) {
+	expand, err := parseExpand(r, "cities")
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	hierarchy, err := h.service.GetHierarchy(r.Context(), expand.Cities)
+	if err != nil {
+		writeError(w, r, err, "hierarchy")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, hierarchy))
+}
+
+// parseExpand reads the comma-separated expand query parameter, accepting
+// only the given collection names.
+func parseExpand(r *http.Request, allowed ...string) (models.Expand, error) {
+	var expand models.Expand
+	param := r.URL.Query().Get("expand")
+	if param == "" {
+		return expand, nil
+	}
+
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(allowed, name) {
+			return expand, errors.InvalidParameter("expand", "expand must be a comma-separated list of "+strings.Join(allowed, ", "))
+		}
+		switch name {
+		case "districts":
+			expand.Districts = true
+		case "constituencies":
+			expand.Constituencies = true
+		case "cities":
+			expand.Cities = true
+		}
+	}
+	return expand, nil
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+// languageTagPattern matches a BCP 47 language tag such as "tw", "ee-GH" or
+// "en-US". Only its primary subtag selects the language of names.
+var languageTagPattern = regexp.MustCompile(`^[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*$`)
+
+type localizedNamesKey struct{}
+
+// Language picks the language names are shown in: the lang query parameter,
+// or without one the most preferred language of the Accept-Language header
+// that has localized names. Names without a translation, and languages with
+// none at all, fall back to English, so only a malformed lang is rejected.
+// Responses carry the chosen language in Content-Language and the available
+// ones in X-Available-Languages.
+func Language(service *services.LocationService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := r.URL.Query().Get("lang")
+			if value != "" && !languageTagPattern.MatchString(value) {
+				writeParameterError(w, r, "lang", "lang must be a language code such as tw or ee")
+				return
+			}
+
+			// Without localized names to offer, responses are served in English.
+			languages, err := service.Languages(r.Context())
+			if err != nil {
+				languages = []string{models.DefaultLanguage}
+			}
+
+			var lang string
+			if value != "" {
+				lang = matchLanguage([]string{value}, languages)
+			} else {
+				lang = matchLanguage(acceptedLanguages(r.Header.Get("Accept-Language")), languages)
+			}
+
+			w.Header().Add("Vary", "Accept-Language")
+			w.Header().Set("Content-Language", lang)
+			w.Header().Set("X-Available-Languages", strings.Join(languages, ", "))
+
+			ctx := models.WithLanguage(r.Context(), lang)
+			if names, err := service.LocalizedNames(ctx, lang); err == nil {
+				ctx = context.WithValue(ctx, localizedNamesKey{}, names)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// acceptedLanguages returns the language tags of an Accept-Language header,
+// most preferred first. Tags with q=0 are left out.
+func acceptedLanguages(header string) []string {
+	type accepted struct {
+		tag     string
+		quality float64
+	}
+	var tags []accepted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if tag == "" || quality <= 0 {
+			continue
+		}
+		tags = append(tags, accepted{tag: tag, quality: quality})
+	}
+	slices.SortStableFunc(tags, func(a, b accepted) int {
+		return cmp.Compare(b.quality, a.quality)
+	})
+
+	ordered := make([]string, len(tags))
+	for i, t := range tags {
+		ordered[i] = t.tag
+	}
+	return ordered
+}
+
+// matchLanguage returns the first of tags whose primary subtag is one of
+// languages, or English when none is.
+func matchLanguage(tags []string, languages []string) string {
+	for _, tag := range tags {
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if slices.Contains(languages, primary) {
+			return primary
+		}
+	}
+	return models.DefaultLanguage
+}
+
+// localized returns v with the names of the regions, districts,
+// constituencies and cities in it replaced by their names in the request's
+// language. Names without a translation are left in English. The records
+// keep their English name in name_en, as lists are sorted by it. v is copied
+// first, as the in-memory repositories return records they share between
+// requests.
+func localized[T any](r *http.Request, v T) T {
+	names, _ := r.Context().Value(localizedNamesKey{}).(map[models.NameKey]string)
+	if len(names) == 0 {
+		return v
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var copied T
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return v
+	}
+	localizeValue(reflect.ValueOf(&copied).Elem(), names)
+	return copied
+}
+
+// localizeValue replaces the names found in v.
+func localizeValue(v reflect.Value, names map[models.NameKey]string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			localizeValue(v.Elem(), names)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			localizeValue(v.Index(i), names)
+		}
+	case reflect.Struct:
+		if v.CanAddr() {
+			localizeRecord(v.Addr().Interface(), names)
+		}
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				localizeValue(v.Field(i), names)
+			}
+		}
+	}
+}
+
+// localizeRecord replaces the name of record when it is one of the models
+// that names a region, district, constituency or city.
+func localizeRecord(record any, names map[models.NameKey]string) {
+	rename := func(kind, slug string, name *string) {
+		if localized, ok := names[models.NameKey{Kind: kind, Slug: slug}]; ok {
+			*name = localized
+		}
+	}
+
+	switch x := record.(type) {
+	case *models.Region:
+		x.NameEN = x.Name
+		rename("region", x.Slug, &x.Name)
+	case *models.District:
+		x.NameEN = x.Name
+		rename("district", x.Slug, &x.Name)
+	case *models.Constituency:
+		x.NameEN = x.Name
+		rename("constituency", x.Slug, &x.Name)
+	case *models.City:
+		x.NameEN = x.Name
+		rename("city", x.Slug, &x.Name)
+	case *models.SearchResult:
+		rename(x.Type, x.Slug, &x.Name)
+	case *models.SearchParent:
+		rename(x.Type, x.Slug, &x.Name)
+	case *models.BoundaryProperties:
+		rename(x.Type, x.Slug, &x.Name)
+	case *models.DistrictNode:
+		for i := range x.Successors {
+			rename("district", x.Successors[i].Slug, &x.Successors[i].Name)
+		}
+	case *models.Lineage:
+		for _, links := range [][]models.LineageLink{x.Predecessors, x.Successors} {
+			for i := range links {
+				rename(x.Kind, links[i].Slug, &links[i].Name)
+			}
+		}
+	}
+}
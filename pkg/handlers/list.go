@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+)
+
+// parseListParams reads the limit, cursor, sort and fields query parameters
+// shared by every list endpoint. Requested fields are checked against the JSON
+// fields of T.
+func parseListParams[T any](r *http.Request) (models.ListOptions, []string, error) {
+	query := r.URL.Query()
+	opts := models.ListOptions{
+		Cursor: query.Get("cursor"),
+		Sort:   query.Get("sort"),
+	}
+
+	if limitParam := query.Get("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit < 1 {
+			return opts, nil, errors.InvalidParameter("limit", "invalid limit parameter")
+		}
+		opts.Limit = limit
+	}
+
+	var fields []string
+	if fieldsParam := query.Get("fields"); fieldsParam != "" {
+		known := jsonFieldNames(reflect.TypeFor[T]())
+		for _, field := range strings.Split(fieldsParam, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if !known[field] {
+				return opts, nil, errors.InvalidParameter("fields", "unknown field: "+field)
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	return opts, fields, nil
+}
+
+// writeList writes a list envelope in the request's language, keeping only
+// the requested fields of each item.
+func writeList[T any](w http.ResponseWriter, r *http.Request, list *models.List[T], fields []string) {
+	list = localized(r, list)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+
+	if len(fields) == 0 {
+		json.NewEncoder(w).Encode(list)
+		return
+	}
+
+	projected := models.List[map[string]json.RawMessage]{
+		Data:       make([]map[string]json.RawMessage, 0, len(list.Data)),
+		NextCursor: list.NextCursor,
+		Total:      list.Total,
+	}
+	for _, item := range list.Data {
+		data, _ := json.Marshal(item)
+		var all map[string]json.RawMessage
+		json.Unmarshal(data, &all)
+
+		selected := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := all[field]; ok {
+				selected[field] = value
+			}
+		}
+		projected.Data = append(projected.Data, selected)
+	}
+	json.NewEncoder(w).Encode(projected)
+}
+
+// jsonFieldNames returns the JSON field names of struct type t, including
+// those of embedded structs such as models.Validity.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			maps.Copy(names, jsonFieldNames(field.Type))
+			continue
+		}
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
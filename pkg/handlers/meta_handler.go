@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ghana-location-api/pkg/services"
+)
+
+type MetaHandler struct {
+	service *services.LocationService
+}
+
+func NewMetaHandler(service *services.LocationService) *MetaHandler {
+	return &MetaHandler{service: service}
+}
+
+// Get returns the dataset version and the number of locations of each kind.
+func (h *MetaHandler) Get(w http.ResponseWriter, r *http.Request) {
+	meta, err := h.service.GetMeta(r.Context())
+	if err != nil {
+		writeError(w, r, err, "metadata")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", metaCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(meta)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ghana-location-api/pkg/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type PollingStationHandler struct {
+	service *services.LocationService
+}
+
+func NewPollingStationHandler(service *services.LocationService) *PollingStationHandler {
+	return &PollingStationHandler{service: service}
+}
+
+func (h *PollingStationHandler) GetByCode(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	if code == "" {
+		writeParameterError(w, r, "code", "polling station code is required")
+		return
+	}
+
+	station, err := h.service.GetPollingStationByCode(r.Context(), code)
+	if err != nil {
+		writeError(w, r, err, "polling station")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, station))
+}
+
+func (h *PollingStationHandler) Decode(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	if code == "" {
+		writeParameterError(w, r, "code", "polling station code is required")
+		return
+	}
+
+	decoded, err := h.service.DecodePollingStationCode(r.Context(), code)
+	if err != nil {
+		writeError(w, r, err, "polling station code")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, decoded))
+}
@@ -1,81 +1,123 @@
-package handlers
-
-import (
-	"encoding/json"
-	"net/http"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/ghana-location-api/pkg/errors"
-	"github.com/ghana-location-api/pkg/services"
-)
-
-type RegionHandler struct {
-	service *services.LocationService
-}
-
-func NewRegionHandler(service *services.LocationService) *RegionHandler {
-	return &RegionHandler{service: service}
-}
-
-func (h *RegionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	regions, err := h.service.GetAllRegions(r.Context())
-	if err != nil {
-		errors.WriteError(w, http.StatusInternalServerError, "failed to fetch regions")
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "public, max-age=3600")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(regions)
-}
-
-func (h *RegionHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
-	slug := chi.URLParam(r, "slug")
-	if slug == "" {
-		errors.WriteError(w, http.StatusBadRequest, "region slug is required")
-		return
-	}
-
-	region, err := h.service.GetRegionBySlug(r.Context(), slug)
-	if err != nil {
-		if err == errors.ErrNotFound {
-			errors.WriteError(w, http.StatusNotFound, "region not found")
-			return
-		}
-		if err == errors.ErrInvalidSlug {
-			errors.WriteError(w, http.StatusBadRequest, "invalid slug format")
-			return
-		}
-		errors.WriteError(w, http.StatusInternalServerError, "failed to fetch region")
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "public, max-age=3600")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(region)
-}
-
-func (h *RegionHandler) GetDistricts(w http.ResponseWriter, r *http.Request) {
-	slug := chi.URLParam(r, "slug")
-	if slug == "" {
-		errors.WriteError(w, http.StatusBadRequest, "region slug is required")
-		return
-	}
-
-	districts, err := h.service.GetDistrictsByRegionSlug(r.Context(), slug)
-	if err != nil {
-		if err == errors.ErrInvalidSlug {
-			errors.WriteError(w, http.StatusBadRequest, "invalid slug format")
-			return
-		}
-		errors.WriteError(w, http.StatusInternalServerError, "failed to fetch districts")
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "public, max-age=3600")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(districts)
-}
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+type RegionHandler struct {
+	service *services.LocationService
+}
+
+func NewRegionHandler(service *services.LocationService) *RegionHandler {
+	return &RegionHandler{service: service}
+}
+
+func (h *RegionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	opts, fields, err := parseListParams[models.Region](r)
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	regions, err := h.service.GetAllRegions(r.Context(), opts)
+	if err != nil {
+		writeError(w, r, err, "regions")
+		return
+	}
+
+	writeList(w, r, regions, fields)
+}
+
+func (h *RegionHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "region slug is required")
+		return
+	}
+
+	expand, err := parseExpand(r, "districts", "constituencies", "cities")
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	region, err := h.service.GetRegionTree(r.Context(), slug, expand)
+	if err != nil {
+		writeError(w, r, err, "region")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, region))
+}
+
+func (h *RegionHandler) GetDistricts(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "region slug is required")
+		return
+	}
+
+	opts, fields, err := parseListParams[models.District](r)
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	districts, err := h.service.GetDistrictsByRegionSlug(r.Context(), slug, opts)
+	if err != nil {
+		writeError(w, r, err, "districts")
+		return
+	}
+
+	writeList(w, r, districts, fields)
+}
+
+func (h *RegionHandler) GetConstituencies(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "region slug is required")
+		return
+	}
+
+	opts, fields, err := parseListParams[models.Constituency](r)
+	if err != nil {
+		errors.WriteError(w, r, err, err.Error())
+		return
+	}
+
+	constituencies, err := h.service.GetConstituenciesByRegionSlug(r.Context(), slug, opts)
+	if err != nil {
+		writeError(w, r, err, "constituencies")
+		return
+	}
+
+	writeList(w, r, constituencies, fields)
+}
+
+func (h *RegionHandler) GetBoundary(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		writeParameterError(w, r, "slug", "region slug is required")
+		return
+	}
+
+	boundary, err := h.service.GetRegionBoundary(r.Context(), slug)
+	if err != nil {
+		writeError(w, r, err, "region boundary")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/geo+json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, boundary))
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+// Routes registers the API routes served by service, with the as_of, language
+// and conditional request middleware. HEAD requests are served by the GET
+// handlers. Mount it under /api/v1.
+func Routes(service *services.LocationService) func(chi.Router) {
+	countryHandler := NewCountryHandler(service)
+	regionHandler := NewRegionHandler(service)
+	districtHandler := NewDistrictHandler(service)
+	constituencyHandler := NewConstituencyHandler(service)
+	cityHandler := NewCityHandler(service)
+	pollingStationHandler := NewPollingStationHandler(service)
+	searchHandler := NewSearchHandler(service)
+	hierarchyHandler := NewHierarchyHandler(service)
+	metaHandler := NewMetaHandler(service)
+
+	return func(r chi.Router) {
+		r.Use(AsOf)
+		r.Use(Language(service))
+		r.Use(middleware.GetHead)
+
+		r.Group(func(r chi.Router) {
+			r.Use(ConditionalGet(service, dataCacheControl))
+
+			// Countries
+			r.Get("/countries", countryHandler.GetAll)
+			r.Get("/countries/{code}", countryHandler.GetByCode)
+
+			// Regions
+			r.Get("/regions", regionHandler.GetAll)
+			r.Get("/regions/{slug}", regionHandler.GetBySlug)
+			r.Get("/regions/{slug}/districts", regionHandler.GetDistricts)
+			r.Get("/regions/{slug}/constituencies", regionHandler.GetConstituencies)
+			r.Get("/regions/{slug}/boundary", regionHandler.GetBoundary)
+
+			// Districts
+			r.Get("/districts", districtHandler.GetAll)
+			r.Get("/districts/{slug}", districtHandler.GetBySlug)
+			r.Get("/districts/{slug}/constituencies", districtHandler.GetConstituencies)
+			r.Get("/districts/{slug}/boundary", districtHandler.GetBoundary)
+			r.Get("/districts/{slug}/lineage", districtHandler.GetLineage)
+
+			// Constituencies
+			r.Get("/constituencies", constituencyHandler.GetAll)
+			r.Get("/constituencies/{slug}", constituencyHandler.GetBySlug)
+			r.Get("/constituencies/{slug}/polling-stations", constituencyHandler.GetPollingStations)
+			r.Get("/constituencies/{slug}/lineage", constituencyHandler.GetLineage)
+
+			// Cities
+			r.Get("/cities", cityHandler.GetAll)
+			r.Get("/cities/{slug}", cityHandler.GetBySlug)
+			r.Get("/cities/{slug}/nearby", cityHandler.Nearby)
+			r.Get("/distance", cityHandler.Distance)
+
+			// Polling stations
+			r.Get("/polling-stations/{code}", pollingStationHandler.GetByCode)
+			r.Get("/polling-station-codes/{code}/decode", pollingStationHandler.Decode)
+
+			// Hierarchy
+			r.Get("/hierarchy", hierarchyHandler.Get)
+
+			// Search
+			r.Get("/search", searchHandler.Search)
+
+			// Reverse geocoding
+			r.Get("/reverse", cityHandler.Reverse)
+		})
+
+		// Dataset metadata
+		r.Group(func(r chi.Router) {
+			r.Use(ConditionalGet(service, metaCacheControl))
+			r.Get("/meta", metaHandler.Get)
+		})
+	}
+}
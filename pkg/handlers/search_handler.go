@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ghana-location-api/pkg/services"
+)
+
+type SearchHandler struct {
+	service *services.LocationService
+}
+
+func NewSearchHandler(service *services.LocationService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		writeParameterError(w, r, "q", "q parameter is required")
+		return
+	}
+
+	var types []string
+	if typeParam := r.URL.Query().Get("type"); typeParam != "" {
+		for _, t := range strings.Split(typeParam, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				types = append(types, t)
+			}
+		}
+	}
+
+	limit := 0
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			writeParameterError(w, r, "limit", "invalid limit parameter")
+			return
+		}
+	}
+
+	results, err := h.service.Search(r.Context(), query, types, limit)
+	if err != nil {
+		writeError(w, r, err, "search results")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", dataCacheControl)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(localized(r, results))
+}
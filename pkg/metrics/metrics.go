@@ -0,0 +1,256 @@
+// Package metrics records HTTP request metrics and exposes them, with the
+// database pool and query cache stats, in the Prometheus text format.
+package metrics
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/ghana-location-api/pkg/cache"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const namespace = "ghana_location_api"
+
+// buckets are the upper bounds, in seconds, of the request duration
+// histogram. They match the Prometheus client defaults.
+var buckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// unmatchedRoute labels requests that matched no route, so that arbitrary
+// paths do not each get their own series. chi reports those that fall
+// through a subrouter under its mount pattern, such as /api/v1/*; no route of
+// the API ends in a wildcard, so such patterns are unmatched too.
+const unmatchedRoute = "unmatched"
+
+type requestKey struct {
+	method string
+	route  string
+	status int
+}
+
+type durationKey struct {
+	method string
+	route  string
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// Metrics collects request counts and durations by chi route pattern.
+type Metrics struct {
+	pool  *pgxpool.Pool
+	cache *cache.Cache
+
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	durations map[durationKey]*histogram
+}
+
+// New returns Metrics that also report the stats of pool and cache. Either
+// may be nil when the backend has no database pool or query cache.
+func New(pool *pgxpool.Pool, queryCache *cache.Cache) *Metrics {
+	return &Metrics{
+		pool:      pool,
+		cache:     queryCache,
+		requests:  make(map[requestKey]uint64),
+		durations: make(map[durationKey]*histogram),
+	}
+}
+
+// Middleware records the status and duration of every request under the
+// route pattern chi matched it to, such as /api/v1/regions/{slug}.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := unmatchedRoute
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" && !strings.HasSuffix(pattern, "/*") {
+				route = pattern
+			}
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		m.observe(method(r), route, status, time.Since(start))
+	})
+}
+
+// method returns the request method, or "other" for a nonstandard one, so
+// that clients cannot create series at will.
+func method(r *http.Request) string {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions, http.MethodConnect, http.MethodTrace:
+		return r.Method
+	default:
+		return "other"
+	}
+}
+
+func (m *Metrics) observe(method, route string, status int, elapsed time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{method, route, status}]++
+
+	key := durationKey{method, route}
+	h, ok := m.durations[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(buckets))}
+		m.durations[key] = h
+	}
+	seconds := elapsed.Seconds()
+	if i, _ := slices.BinarySearch(buckets, seconds); i < len(buckets) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ServeHTTP writes every metric in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.writeRequests(w)
+	if m.pool != nil {
+		writePool(w, m.pool.Stat())
+	}
+	if m.cache != nil {
+		writeCache(w, m.cache.Stats())
+	}
+}
+
+func (m *Metrics) writeRequests(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requestKeys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		requestKeys = append(requestKeys, key)
+	}
+	slices.SortFunc(requestKeys, func(a, b requestKey) int {
+		return cmp.Or(strings.Compare(a.route, b.route), strings.Compare(a.method, b.method), cmp.Compare(a.status, b.status))
+	})
+
+	name := namespace + "_http_requests_total"
+	writeHeader(w, name, "counter", "HTTP requests by method, route pattern and status.")
+	for _, key := range requestKeys {
+		fmt.Fprintf(w, "%s{method=\"%s\",route=\"%s\",status=\"%d\"} %d\n", name, key.method, escape(key.route), key.status, m.requests[key])
+	}
+
+	durationKeys := make([]durationKey, 0, len(m.durations))
+	for key := range m.durations {
+		durationKeys = append(durationKeys, key)
+	}
+	slices.SortFunc(durationKeys, func(a, b durationKey) int {
+		return cmp.Or(strings.Compare(a.route, b.route), strings.Compare(a.method, b.method))
+	})
+
+	name = namespace + "_http_request_duration_seconds"
+	writeHeader(w, name, "histogram", "HTTP request durations by method and route pattern.")
+	for _, key := range durationKeys {
+		h := m.durations[key]
+		labels := fmt.Sprintf("method=\"%s\",route=\"%s\"", key.method, escape(key.route))
+		var cumulative uint64
+		for i, bound := range buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, formatFloat(bound), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", name, labels, formatFloat(h.sum))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, h.count)
+	}
+}
+
+func writePool(w io.Writer, stat *pgxpool.Stat) {
+	gauges := []struct {
+		name, help string
+		value      int32
+	}{
+		{"db_pool_acquired_connections", "Connections currently checked out of the pool.", stat.AcquiredConns()},
+		{"db_pool_idle_connections", "Idle connections in the pool.", stat.IdleConns()},
+		{"db_pool_total_connections", "Connections in the pool, including ones being opened.", stat.TotalConns()},
+		{"db_pool_max_connections", "Maximum size of the pool.", stat.MaxConns()},
+	}
+	for _, g := range gauges {
+		name := namespace + "_" + g.name
+		writeHeader(w, name, "gauge", g.help)
+		fmt.Fprintf(w, "%s %d\n", name, g.value)
+	}
+
+	counters := []struct {
+		name, help string
+		value      string
+	}{
+		{"db_pool_acquires_total", "Connections acquired from the pool.", strconv.FormatInt(stat.AcquireCount(), 10)},
+		{"db_pool_empty_acquires_total", "Acquires that waited because the pool had no idle connection.", strconv.FormatInt(stat.EmptyAcquireCount(), 10)},
+		{"db_pool_canceled_acquires_total", "Acquires canceled by their context.", strconv.FormatInt(stat.CanceledAcquireCount(), 10)},
+		{"db_pool_acquire_duration_seconds_total", "Time spent acquiring connections.", formatFloat(stat.AcquireDuration().Seconds())},
+		{"db_pool_wait_duration_seconds_total", "Time spent waiting for a connection when the pool had none idle.", formatFloat(stat.EmptyAcquireWaitTime().Seconds())},
+	}
+	for _, c := range counters {
+		name := namespace + "_" + c.name
+		writeHeader(w, name, "counter", c.help)
+		fmt.Fprintf(w, "%s %s\n", name, c.value)
+	}
+}
+
+func writeCache(w io.Writer, stats map[string]cache.Stats) {
+	kinds := make([]string, 0, len(stats))
+	for kind := range stats {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	series := []struct {
+		name, typ, help string
+		value           func(cache.Stats) string
+	}{
+		{"cache_hits_total", "counter", "Query cache hits by query kind.", func(s cache.Stats) string { return strconv.FormatUint(s.Hits, 10) }},
+		{"cache_misses_total", "counter", "Query cache misses by query kind.", func(s cache.Stats) string { return strconv.FormatUint(s.Misses, 10) }},
+		{"cache_errors_total", "counter", "Query cache store errors by query kind.", func(s cache.Stats) string { return strconv.FormatUint(s.Errors, 10) }},
+		{"cache_hit_ratio", "gauge", "Share of query cache lookups that were hits, by query kind.", func(s cache.Stats) string {
+			if s.Hits+s.Misses == 0 {
+				return "0"
+			}
+			return formatFloat(float64(s.Hits) / float64(s.Hits+s.Misses))
+		}},
+	}
+	for _, s := range series {
+		name := namespace + "_" + s.name
+		writeHeader(w, name, s.typ, s.help)
+		for _, kind := range kinds {
+			fmt.Fprintf(w, "%s{kind=\"%s\"} %s\n", name, escape(kind), s.value(stats[kind]))
+		}
+	}
+}
+
+func writeHeader(w io.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// escape escapes a label value as the exposition format requires.
+func escape(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
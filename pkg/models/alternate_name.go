@@ -0,0 +1,10 @@
+package models
+
+// AlternateName is another name a region, district, constituency or city is
+// known by: a historical name, a spelling variant or an abbreviation.
+type AlternateName struct {
+	Kind string `json:"kind"` // region, district, constituency, city
+	Slug string `json:"slug"` // of the record it names
+	Name string `json:"name"`
+	Type string `json:"type"` // historical, variant, abbreviation
+}
@@ -0,0 +1,16 @@
+package models
+
+import "encoding/json"
+
+// Boundary is a GeoJSON Feature holding the boundary polygon of a region or district.
+type Boundary struct {
+	Type       string             `json:"type"`
+	Properties BoundaryProperties `json:"properties"`
+	Geometry   json.RawMessage    `json:"geometry"`
+}
+
+type BoundaryProperties struct {
+	Type string `json:"type"` // region, district
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
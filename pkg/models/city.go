@@ -1,9 +1,18 @@
-package models
-
-type City struct {
-	ID         string   `json:"id"`
-	DistrictID string   `json:"district_id"`
-	Name       string   `json:"name"`
-	Lat        *float64 `json:"lat,omitempty"`
-	Lng        *float64 `json:"lng,omitempty"`
-}
+package models
+
+type City struct {
+	ID         string   `json:"id"`
+	DistrictID string   `json:"district_id"`
+	Name       string   `json:"name"`
+	NameEN     string   `json:"name_en,omitempty"` // set when names are shown in another language
+	Slug       string   `json:"slug"`
+	Lat        *float64 `json:"lat,omitempty"`
+	Lng        *float64 `json:"lng,omitempty"`
+}
+
+// CityDetail is a city with the district and region it belongs to.
+type CityDetail struct {
+	City
+	District *District `json:"district"`
+	Region   *Region   `json:"region"`
+}
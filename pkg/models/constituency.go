@@ -1,8 +1,18 @@
-package models
-
-type Constituency struct {
-	ID         string  `json:"id"`
-	DistrictID *string `json:"district_id,omitempty"`
-	Name       string  `json:"name"`
-	Slug       string  `json:"slug"`
-}
+package models
+
+type Constituency struct {
+	ID         string  `json:"id"`
+	DistrictID *string `json:"district_id,omitempty"`
+	RegionID   *string `json:"region_id,omitempty"`
+	Name       string  `json:"name"`
+	NameEN     string  `json:"name_en,omitempty"` // set when names are shown in another language
+	Slug       string  `json:"slug"`
+	Validity
+}
+
+// ConstituencyDetail is a constituency with the district and region it belongs to.
+type ConstituencyDetail struct {
+	Constituency
+	District *District `json:"district"`
+	Region   *Region   `json:"region"`
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateLayout is the format of dates in requests, responses and data files.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date, written as YYYY-MM-DD.
+type Date struct {
+	time.Time
+}
+
+// ParseDate parses a date in DateLayout.
+func ParseDate(value string) (Date, error) {
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+// Today returns the current date in UTC, which is Ghana's time zone.
+func Today() Date {
+	now := time.Now().UTC()
+	return Date{time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner so DATE columns can be scanned into a Date.
+func (d *Date) Scan(src any) error {
+	t, ok := src.(time.Time)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into Date", src)
+	}
+	d.Time = t
+	return nil
+}
+
+// Validity is the period a region, district or constituency existed in:
+// from ValidFrom up to, but not including, ValidTo. A nil ValidFrom means it
+// existed before records begin, and a nil ValidTo that it still exists.
+type Validity struct {
+	ValidFrom *Date `json:"valid_from,omitempty"`
+	ValidTo   *Date `json:"valid_to,omitempty"`
+}
+
+// ValidOn reports whether date falls within the period.
+func (v Validity) ValidOn(date Date) bool {
+	if v.ValidFrom != nil && date.Before(v.ValidFrom.Time) {
+		return false
+	}
+	return v.ValidTo == nil || date.Before(v.ValidTo.Time)
+}
+
+type asOfKey struct{}
+
+// WithAsOf returns a context in which the hierarchy is queried as it stood
+// on date.
+func WithAsOf(ctx context.Context, date Date) context.Context {
+	return context.WithValue(ctx, asOfKey{}, date)
+}
+
+// AsOf returns the date set with WithAsOf, if any. Lookups by slug or code
+// only check validity when one is set, so retired records still resolve.
+func AsOf(ctx context.Context) (Date, bool) {
+	date, ok := ctx.Value(asOfKey{}).(Date)
+	return date, ok
+}
+
+// ValidityDate returns the date lists are filtered on: the date set with
+// WithAsOf, or today.
+func ValidityDate(ctx context.Context) Date {
+	if date, ok := AsOf(ctx); ok {
+		return date
+	}
+	return Today()
+}
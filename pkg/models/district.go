@@ -1,10 +1,12 @@
-package models
-
-type District struct {
-	ID       string  `json:"id"`
-	RegionID string `json:"region_id"`
-	Name     string  `json:"name"`
-	Slug     string  `json:"slug"`
-	Type     string  `json:"type"` // metro, municipal, district
-	Capital  *string `json:"capital,omitempty"`
-}
+package models
+
+type District struct {
+	ID       string  `json:"id"`
+	RegionID string `json:"region_id"`
+	Name     string  `json:"name"`
+	NameEN   string  `json:"name_en,omitempty"` // set when names are shown in another language
+	Slug     string  `json:"slug"`
+	Type     string  `json:"type"` // metro, municipal, district
+	Capital  *string `json:"capital,omitempty"`
+	Validity
+}
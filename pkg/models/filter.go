@@ -0,0 +1,20 @@
+package models
+
+// DistrictFilter narrows a district list. Empty fields are ignored.
+type DistrictFilter struct {
+	RegionSlug string
+	Type       string // metro, municipal, district
+}
+
+// ConstituencyFilter narrows a constituency list. Empty fields are ignored.
+type ConstituencyFilter struct {
+	RegionSlug   string
+	DistrictSlug string
+}
+
+// CityFilter narrows a city list. Empty fields are ignored.
+type CityFilter struct {
+	RegionSlug     string
+	DistrictSlug   string
+	HasCoordinates *bool
+}
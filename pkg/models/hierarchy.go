@@ -0,0 +1,33 @@
+package models
+
+// Expand selects the child collections nested into a region or district.
+type Expand struct {
+	Districts      bool
+	Constituencies bool
+	Cities         bool
+}
+
+// RegionNode is a region with its districts nested when expanded. When
+// constituencies are expanded, those not linked to a district are nested in
+// the region itself, so every constituency appears once in the tree.
+type RegionNode struct {
+	Region
+	Districts      []DistrictNode `json:"districts,omitzero"`
+	Constituencies []Constituency `json:"constituencies,omitzero"`
+}
+
+// DistrictNode is a district with its constituencies and cities nested when
+// expanded. Successors is only set for a retired district, to point to the
+// current districts that replaced it.
+type DistrictNode struct {
+	District
+	Constituencies []Constituency `json:"constituencies,omitzero"`
+	Cities         []City         `json:"cities,omitzero"`
+	Successors     []LineageLink  `json:"successors,omitempty"`
+}
+
+// Hierarchy is the whole country tree.
+type Hierarchy struct {
+	Country *Country     `json:"country"`
+	Regions []RegionNode `json:"regions"`
+}
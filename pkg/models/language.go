@@ -0,0 +1,38 @@
+package models
+
+import "context"
+
+// DefaultLanguage is the language of the names in the hierarchy itself, and
+// of every name without a translation.
+const DefaultLanguage = "en"
+
+// LocalizedName is the name of a region, district, constituency or city in a
+// language other than English.
+type LocalizedName struct {
+	Kind string `json:"kind"` // region, district, constituency, city
+	Slug string `json:"slug"` // of the record it names
+	Lang string `json:"lang"`
+	Name string `json:"name"`
+}
+
+// NameKey identifies the record a localized name belongs to.
+type NameKey struct {
+	Kind string
+	Slug string
+}
+
+type languageKey struct{}
+
+// WithLanguage returns a context in which names are shown in lang, falling
+// back to English where lang has no name.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// Language returns the language set with WithLanguage, or DefaultLanguage.
+func Language(ctx context.Context) string {
+	if lang, ok := ctx.Value(languageKey{}).(string); ok {
+		return lang
+	}
+	return DefaultLanguage
+}
@@ -0,0 +1,20 @@
+package models
+
+// LineageLink connects a district or constituency to a record it was split,
+// merged or renamed from or into.
+type LineageLink struct {
+	Type string `json:"type"` // split, merge, rename
+	Date Date   `json:"date"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// Lineage lists the records a district or constituency was formed from and
+// the records formed from it, oldest first. Kind is "district" or
+// "constituency".
+type Lineage struct {
+	Kind         string        `json:"kind"`
+	Slug         string        `json:"slug"`
+	Predecessors []LineageLink `json:"predecessors"`
+	Successors   []LineageLink `json:"successors"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// List is the envelope returned by every list endpoint.
+type List[T any] struct {
+	Data       []T     `json:"data"`
+	NextCursor *string `json:"next_cursor"`
+	Total      int     `json:"total"`
+}
+
+// ListOptions controls paging and ordering of a list query.
+type ListOptions struct {
+	Limit  int
+	Cursor string
+	// Sort is a field name, optionally prefixed with "-" for descending order.
+	Sort string
+}
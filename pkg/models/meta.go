@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// DatasetVersion identifies the data being served. Version changes whenever
+// the data files do; SeededAt is when that data was loaded, and is zero when
+// that is not known, as for the embedded data files. Tables lists the tables
+// the latest seed loaded when it loaded only some of them; the others hold
+// data from an earlier seed. Seed numbers the recorded seeds, and is zero
+// for the embedded data files.
+type DatasetVersion struct {
+	Seed     int       `json:"-"`
+	Version  string    `json:"version"`
+	SeededAt time.Time `json:"seeded_at,omitzero"`
+	Tables   []string  `json:"tables,omitempty"`
+}
+
+// Revision identifies the data being served. Unlike Version, which only
+// digests the data files, it changes with every recorded seed, including a
+// seed of some tables that leaves Version as it was.
+func (v DatasetVersion) Revision() string {
+	if v.Seed == 0 {
+		return v.Version
+	}
+	return v.Version + "." + strconv.Itoa(v.Seed)
+}
+
+// Counts is the number of locations of each kind.
+type Counts struct {
+	Countries       int `json:"countries"`
+	Regions         int `json:"regions"`
+	Districts       int `json:"districts"`
+	Constituencies  int `json:"constituencies"`
+	Cities          int `json:"cities"`
+	PollingStations int `json:"polling_stations"`
+}
+
+// Meta describes the dataset the API serves. Dataset is nil until the
+// database has been seeded. Languages lists the languages names can be shown
+// in, English first.
+type Meta struct {
+	Dataset   *DatasetVersion `json:"dataset"`
+	Counts    Counts          `json:"counts"`
+	Languages []string        `json:"languages"`
+}
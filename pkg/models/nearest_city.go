@@ -0,0 +1,33 @@
+package models
+
+// NearestCity is a city returned by a reverse lookup, with its distance from
+// the requested point and the administrative areas it belongs to.
+type NearestCity struct {
+	City           City           `json:"city"`
+	DistanceKm     float64        `json:"distance_km"`
+	District       District       `json:"district"`
+	Region         Region         `json:"region"`
+	Constituencies []Constituency `json:"constituencies"`
+}
+
+// ReverseGeocodeResult answers "where is this point?": the region and district
+// whose boundaries contain it (when boundaries are imported) and the nearest cities.
+type ReverseGeocodeResult struct {
+	Region   *Region       `json:"region"`
+	District *District     `json:"district"`
+	Cities   []NearestCity `json:"cities"`
+}
+
+// NearbyCities lists the cities within RadiusKm of a city, nearest first.
+type NearbyCities struct {
+	City     City          `json:"city"`
+	RadiusKm float64       `json:"radius_km"`
+	Cities   []NearestCity `json:"cities"`
+}
+
+// CityDistance is the great-circle distance between two cities.
+type CityDistance struct {
+	From       City    `json:"from"`
+	To         City    `json:"to"`
+	DistanceKm float64 `json:"distance_km"`
+}
@@ -0,0 +1,28 @@
+package models
+
+type PollingStation struct {
+	ID             string  `json:"id"`
+	RegionID       string  `json:"region_id"`
+	DistrictID     *string `json:"district_id,omitempty"`
+	ConstituencyID *string `json:"constituency_id,omitempty"`
+	Code           string  `json:"code"`
+	Name           string  `json:"name"`
+}
+
+// PollingStationCode is a polling station code decoded into its parts and
+// the region and constituency they refer to. Constituency is the station's
+// own when a station has the code, and otherwise the one with the
+// constituency number; it is nil when the number is not known or is shared
+// by constituencies split from one another. PollingStation is nil when no
+// station has the code.
+type PollingStationCode struct {
+	Code               string          `json:"code"`
+	RegionLetter       string          `json:"region_letter"`
+	ConstituencyNumber int             `json:"constituency_number"`
+	ElectoralArea      int             `json:"electoral_area"`
+	StationNumber      int             `json:"station_number"`
+	Split              string          `json:"split,omitempty"`
+	Region             Region          `json:"region"`
+	Constituency       *Constituency   `json:"constituency"`
+	PollingStation     *PollingStation `json:"polling_station"`
+}
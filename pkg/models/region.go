@@ -1,9 +1,11 @@
-package models
-
-type Region struct {
-	ID       string  `json:"id"`
-	CountryID string `json:"country_id"`
-	Name     string  `json:"name"`
-	Slug     string  `json:"slug"`
-	Capital  *string `json:"capital,omitempty"`
-}
+package models
+
+type Region struct {
+	ID       string  `json:"id"`
+	CountryID string `json:"country_id"`
+	Name     string  `json:"name"`
+	NameEN   string  `json:"name_en,omitempty"` // set when names are shown in another language
+	Slug     string  `json:"slug"`
+	Capital  *string `json:"capital,omitempty"`
+	Validity
+}
@@ -0,0 +1,18 @@
+package models
+
+// SearchResult is a single search hit together with its chain of parents,
+// ordered from the nearest parent up to the region.
+type SearchResult struct {
+	Type    string         `json:"type"` // region, district, constituency, city
+	ID      string         `json:"id"`
+	Name    string         `json:"name"`
+	Slug    string         `json:"slug,omitempty"`
+	Score   float64        `json:"score"`
+	Parents []SearchParent `json:"parents"`
+}
+
+type SearchParent struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
@@ -0,0 +1,47 @@
+// Package pollingcode decodes Electoral Commission polling station codes.
+package pollingcode
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ghana-location-api/pkg/errors"
+)
+
+// pattern matches a region letter, three two-digit numbers and an optional
+// split letter.
+var pattern = regexp.MustCompile(`^([A-Z])([0-9]{2})([0-9]{2})([0-9]{2})([A-Z]?)$`)
+
+// Code is a polling station code split into its parts. In A010201B, A is the
+// region letter, 01 the constituency within the region, 02 the electoral area
+// within the constituency and 01 the station within the electoral area. The
+// trailing B marks one part of a station whose register was split in two.
+type Code struct {
+	Code          string
+	RegionLetter  string
+	Constituency  int
+	ElectoralArea int
+	Station       int
+	Split         string
+}
+
+// Parse splits code into its parts. Lower-case codes are accepted. Errors
+// match errors.ErrInvalidCode.
+func Parse(code string) (Code, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	match := pattern.FindStringSubmatch(code)
+	if match == nil {
+		return Code{}, fmt.Errorf("%w: %q is not a region letter followed by six digits and an optional split letter", errors.ErrInvalidCode, code)
+	}
+
+	parsed := Code{Code: code, RegionLetter: match[1], Split: match[5]}
+	parsed.Constituency, _ = strconv.Atoi(match[2])
+	parsed.ElectoralArea, _ = strconv.Atoi(match[3])
+	parsed.Station, _ = strconv.Atoi(match[4])
+	if parsed.Constituency == 0 || parsed.ElectoralArea == 0 || parsed.Station == 0 {
+		return Code{}, fmt.Errorf("%w: %q has a zero constituency, electoral area or station number", errors.ErrInvalidCode, code)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,63 @@
+package pollingcode
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/ghana-location-api/pkg/errors"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		code string
+		want Code
+	}{
+		{"A010201", Code{Code: "A010201", RegionLetter: "A", Constituency: 1, ElectoralArea: 2, Station: 1}},
+		{"A010201B", Code{Code: "A010201B", RegionLetter: "A", Constituency: 1, ElectoralArea: 2, Station: 1, Split: "B"}},
+		{"m141203a", Code{Code: "M141203A", RegionLetter: "M", Constituency: 14, ElectoralArea: 12, Station: 3, Split: "A"}},
+		{" F999999 ", Code{Code: "F999999", RegionLetter: "F", Constituency: 99, ElectoralArea: 99, Station: 99}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.code)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.code, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	codes := []string{
+		"",
+		"A",
+		"A01020",    // too short
+		"A01020101", // too long
+		"A010201BC", // two split letters
+		"1010201",   // no region letter
+		"AB010201",  // two region letters
+		"A01O201",   // letter O for zero
+		"A01-02-01", // separators
+		"A000201",   // zero constituency
+		"A010001",   // zero electoral area
+		"A010200",   // zero station
+		"A010201 B", // inner space
+		"Á010201",   // non-ASCII letter
+		"A０10201",   // full-width digit
+	}
+	for _, code := range codes {
+		got, err := Parse(code)
+		if err == nil {
+			t.Errorf("Parse(%q) = %+v, want an error", code, got)
+			continue
+		}
+		if !stderrors.Is(err, errors.ErrInvalidCode) {
+			t.Errorf("Parse(%q) error %v does not match ErrInvalidCode", code, err)
+		}
+		if got != (Code{}) {
+			t.Errorf("Parse(%q) = %+v with error, want a zero Code", code, got)
+		}
+	}
+}
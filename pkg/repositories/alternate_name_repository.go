@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AlternateNameRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAlternateNameRepository(pool *pgxpool.Pool) *AlternateNameRepository {
+	return &AlternateNameRepository{pool: pool}
+}
+
+func (r *AlternateNameRepository) GetByKey(ctx context.Context, kind, key string) (*models.AlternateName, error) {
+	var name models.AlternateName
+	err := r.pool.QueryRow(ctx, "SELECT kind, slug, name, type FROM alternate_names WHERE kind = $1 AND name_key = $2", kind, key).
+		Scan(&name.Kind, &name.Slug, &name.Name, &name.Type)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &name, nil
+}
@@ -0,0 +1,281 @@
+package cached
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/cache"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+type CountryRepository struct {
+	next  services.CountryRepository
+	cache *cache.Cache
+}
+
+func (r *CountryRepository) GetAll(ctx context.Context, opts models.ListOptions) (*models.List[models.Country], error) {
+	return cache.Get(ctx, r.cache, "country.all", []any{opts}, func() (*models.List[models.Country], error) {
+		return r.next.GetAll(ctx, opts)
+	})
+}
+
+func (r *CountryRepository) GetByCode(ctx context.Context, code string) (*models.Country, error) {
+	return cache.Get(ctx, r.cache, "country.code", []any{code}, func() (*models.Country, error) {
+		return r.next.GetByCode(ctx, code)
+	})
+}
+
+type RegionRepository struct {
+	next  services.RegionRepository
+	cache *cache.Cache
+}
+
+func (r *RegionRepository) GetAll(ctx context.Context, opts models.ListOptions) (*models.List[models.Region], error) {
+	return cache.Get(ctx, r.cache, "region.all", []any{opts}, func() (*models.List[models.Region], error) {
+		return r.next.GetAll(ctx, opts)
+	})
+}
+
+func (r *RegionRepository) GetBySlug(ctx context.Context, slug string) (*models.Region, error) {
+	return cache.Get(ctx, r.cache, "region.slug", []any{slug}, func() (*models.Region, error) {
+		return r.next.GetBySlug(ctx, slug)
+	})
+}
+
+func (r *RegionRepository) GetByID(ctx context.Context, id string) (*models.Region, error) {
+	return cache.Get(ctx, r.cache, "region.id", []any{id}, func() (*models.Region, error) {
+		return r.next.GetByID(ctx, id)
+	})
+}
+
+func (r *RegionRepository) GetByECLetter(ctx context.Context, letter string) (*models.Region, error) {
+	return cache.Get(ctx, r.cache, "region.ec_letter", []any{letter}, func() (*models.Region, error) {
+		return r.next.GetByECLetter(ctx, letter)
+	})
+}
+
+func (r *RegionRepository) GetBoundary(ctx context.Context, slug string) (*models.Boundary, error) {
+	return cache.Get(ctx, r.cache, "region.boundary", []any{slug}, func() (*models.Boundary, error) {
+		return r.next.GetBoundary(ctx, slug)
+	})
+}
+
+func (r *RegionRepository) GetContaining(ctx context.Context, lat, lng float64) (*models.Region, error) {
+	return cache.Get(ctx, r.cache, "region.containing", []any{lat, lng}, func() (*models.Region, error) {
+		return r.next.GetContaining(ctx, lat, lng)
+	})
+}
+
+type DistrictRepository struct {
+	next  services.DistrictRepository
+	cache *cache.Cache
+}
+
+func (r *DistrictRepository) GetBySlug(ctx context.Context, slug string) (*models.District, error) {
+	return cache.Get(ctx, r.cache, "district.slug", []any{slug}, func() (*models.District, error) {
+		return r.next.GetBySlug(ctx, slug)
+	})
+}
+
+func (r *DistrictRepository) GetByID(ctx context.Context, id string) (*models.District, error) {
+	return cache.Get(ctx, r.cache, "district.id", []any{id}, func() (*models.District, error) {
+		return r.next.GetByID(ctx, id)
+	})
+}
+
+func (r *DistrictRepository) GetByRegionSlug(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.District], error) {
+	return cache.Get(ctx, r.cache, "district.region", []any{regionSlug, opts}, func() (*models.List[models.District], error) {
+		return r.next.GetByRegionSlug(ctx, regionSlug, opts)
+	})
+}
+
+func (r *DistrictRepository) GetByRegionIDs(ctx context.Context, regionIDs []string) (map[string][]models.District, error) {
+	return cache.Get(ctx, r.cache, "district.region_ids", []any{regionIDs}, func() (map[string][]models.District, error) {
+		return r.next.GetByRegionIDs(ctx, regionIDs)
+	})
+}
+
+func (r *DistrictRepository) List(ctx context.Context, filter models.DistrictFilter, opts models.ListOptions) (*models.List[models.District], error) {
+	return cache.Get(ctx, r.cache, "district.list", []any{filter, opts}, func() (*models.List[models.District], error) {
+		return r.next.List(ctx, filter, opts)
+	})
+}
+
+func (r *DistrictRepository) GetBoundary(ctx context.Context, slug string) (*models.Boundary, error) {
+	return cache.Get(ctx, r.cache, "district.boundary", []any{slug}, func() (*models.Boundary, error) {
+		return r.next.GetBoundary(ctx, slug)
+	})
+}
+
+func (r *DistrictRepository) GetContaining(ctx context.Context, lat, lng float64) (*models.District, error) {
+	return cache.Get(ctx, r.cache, "district.containing", []any{lat, lng}, func() (*models.District, error) {
+		return r.next.GetContaining(ctx, lat, lng)
+	})
+}
+
+type ConstituencyRepository struct {
+	next  services.ConstituencyRepository
+	cache *cache.Cache
+}
+
+func (r *ConstituencyRepository) GetBySlug(ctx context.Context, slug string) (*models.Constituency, error) {
+	return cache.Get(ctx, r.cache, "constituency.slug", []any{slug}, func() (*models.Constituency, error) {
+		return r.next.GetBySlug(ctx, slug)
+	})
+}
+
+func (r *ConstituencyRepository) GetByID(ctx context.Context, id string) (*models.Constituency, error) {
+	return cache.Get(ctx, r.cache, "constituency.id", []any{id}, func() (*models.Constituency, error) {
+		return r.next.GetByID(ctx, id)
+	})
+}
+
+func (r *ConstituencyRepository) GetByECNumber(ctx context.Context, regionID string, number int) (*models.Constituency, error) {
+	return cache.Get(ctx, r.cache, "constituency.ec_number", []any{regionID, number}, func() (*models.Constituency, error) {
+		return r.next.GetByECNumber(ctx, regionID, number)
+	})
+}
+
+func (r *ConstituencyRepository) GetByDistrictSlug(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	return cache.Get(ctx, r.cache, "constituency.district", []any{districtSlug, opts}, func() (*models.List[models.Constituency], error) {
+		return r.next.GetByDistrictSlug(ctx, districtSlug, opts)
+	})
+}
+
+func (r *ConstituencyRepository) GetByRegionSlug(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	return cache.Get(ctx, r.cache, "constituency.region", []any{regionSlug, opts}, func() (*models.List[models.Constituency], error) {
+		return r.next.GetByRegionSlug(ctx, regionSlug, opts)
+	})
+}
+
+func (r *ConstituencyRepository) GetByDistrictIDs(ctx context.Context, districtIDs []string) (map[string][]models.Constituency, error) {
+	return cache.Get(ctx, r.cache, "constituency.district_ids", []any{districtIDs}, func() (map[string][]models.Constituency, error) {
+		return r.next.GetByDistrictIDs(ctx, districtIDs)
+	})
+}
+
+func (r *ConstituencyRepository) GetWithoutDistrictByRegionIDs(ctx context.Context, regionIDs []string) (map[string][]models.Constituency, error) {
+	return cache.Get(ctx, r.cache, "constituency.region_ids", []any{regionIDs}, func() (map[string][]models.Constituency, error) {
+		return r.next.GetWithoutDistrictByRegionIDs(ctx, regionIDs)
+	})
+}
+
+func (r *ConstituencyRepository) List(ctx context.Context, filter models.ConstituencyFilter, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	return cache.Get(ctx, r.cache, "constituency.list", []any{filter, opts}, func() (*models.List[models.Constituency], error) {
+		return r.next.List(ctx, filter, opts)
+	})
+}
+
+type CityRepository struct {
+	next  services.CityRepository
+	cache *cache.Cache
+}
+
+func (r *CityRepository) GetBySlug(ctx context.Context, slug string) (*models.City, error) {
+	return cache.Get(ctx, r.cache, "city.slug", []any{slug}, func() (*models.City, error) {
+		return r.next.GetBySlug(ctx, slug)
+	})
+}
+
+func (r *CityRepository) GetByDistrictSlug(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.City], error) {
+	return cache.Get(ctx, r.cache, "city.district", []any{districtSlug, opts}, func() (*models.List[models.City], error) {
+		return r.next.GetByDistrictSlug(ctx, districtSlug, opts)
+	})
+}
+
+func (r *CityRepository) GetByDistrictIDs(ctx context.Context, districtIDs []string) (map[string][]models.City, error) {
+	return cache.Get(ctx, r.cache, "city.district_ids", []any{districtIDs}, func() (map[string][]models.City, error) {
+		return r.next.GetByDistrictIDs(ctx, districtIDs)
+	})
+}
+
+func (r *CityRepository) List(ctx context.Context, filter models.CityFilter, opts models.ListOptions) (*models.List[models.City], error) {
+	return cache.Get(ctx, r.cache, "city.list", []any{filter, opts}, func() (*models.List[models.City], error) {
+		return r.next.List(ctx, filter, opts)
+	})
+}
+
+func (r *CityRepository) GetNearest(ctx context.Context, lat, lng float64, limit int) ([]models.NearestCity, error) {
+	return cache.Get(ctx, r.cache, "city.nearest", []any{lat, lng, limit}, func() ([]models.NearestCity, error) {
+		return r.next.GetNearest(ctx, lat, lng, limit)
+	})
+}
+
+func (r *CityRepository) GetWithinRadius(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]models.NearestCity, error) {
+	return cache.Get(ctx, r.cache, "city.radius", []any{lat, lng, radiusKm, limit}, func() ([]models.NearestCity, error) {
+		return r.next.GetWithinRadius(ctx, lat, lng, radiusKm, limit)
+	})
+}
+
+type PollingStationRepository struct {
+	next  services.PollingStationRepository
+	cache *cache.Cache
+}
+
+func (r *PollingStationRepository) GetByCode(ctx context.Context, code string) (*models.PollingStation, error) {
+	return cache.Get(ctx, r.cache, "polling_station.code", []any{code}, func() (*models.PollingStation, error) {
+		return r.next.GetByCode(ctx, code)
+	})
+}
+
+func (r *PollingStationRepository) GetByConstituencySlug(ctx context.Context, constituencySlug string, opts models.ListOptions) (*models.List[models.PollingStation], error) {
+	return cache.Get(ctx, r.cache, "polling_station.constituency", []any{constituencySlug, opts}, func() (*models.List[models.PollingStation], error) {
+		return r.next.GetByConstituencySlug(ctx, constituencySlug, opts)
+	})
+}
+
+type SearchRepository struct {
+	next  services.SearchRepository
+	cache *cache.Cache
+}
+
+func (r *SearchRepository) Search(ctx context.Context, query string, types []string, limit int) ([]models.SearchResult, error) {
+	return cache.Get(ctx, r.cache, "search", []any{query, types, limit}, func() ([]models.SearchResult, error) {
+		return r.next.Search(ctx, query, types, limit)
+	})
+}
+
+type LineageRepository struct {
+	next  services.LineageRepository
+	cache *cache.Cache
+}
+
+func (r *LineageRepository) GetPredecessors(ctx context.Context, kind, slug string) ([]models.LineageLink, error) {
+	return cache.Get(ctx, r.cache, "lineage.predecessors", []any{kind, slug}, func() ([]models.LineageLink, error) {
+		return r.next.GetPredecessors(ctx, kind, slug)
+	})
+}
+
+func (r *LineageRepository) GetSuccessors(ctx context.Context, kind, slug string) ([]models.LineageLink, error) {
+	return cache.Get(ctx, r.cache, "lineage.successors", []any{kind, slug}, func() ([]models.LineageLink, error) {
+		return r.next.GetSuccessors(ctx, kind, slug)
+	})
+}
+
+type AlternateNameRepository struct {
+	next  services.AlternateNameRepository
+	cache *cache.Cache
+}
+
+func (r *AlternateNameRepository) GetByKey(ctx context.Context, kind, key string) (*models.AlternateName, error) {
+	return cache.Get(ctx, r.cache, "alternate_name.key", []any{kind, key}, func() (*models.AlternateName, error) {
+		return r.next.GetByKey(ctx, kind, key)
+	})
+}
+
+type LocalizedNameRepository struct {
+	next  services.LocalizedNameRepository
+	cache *cache.Cache
+}
+
+func (r *LocalizedNameRepository) GetByLanguage(ctx context.Context, lang string) ([]models.LocalizedName, error) {
+	return cache.Get(ctx, r.cache, "localized_name.language", []any{lang}, func() ([]models.LocalizedName, error) {
+		return r.next.GetByLanguage(ctx, lang)
+	})
+}
+
+func (r *LocalizedNameRepository) GetLanguages(ctx context.Context) ([]string, error) {
+	return cache.Get(ctx, r.cache, "localized_name.languages", nil, func() ([]string, error) {
+		return r.next.GetLanguages(ctx)
+	})
+}
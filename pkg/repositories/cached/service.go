@@ -0,0 +1,55 @@
+// Package cached decorates the LocationService repositories with a cache.
+// Each query result is cached under its arguments and the dataset version,
+// so reseeding the database invalidates everything cached before it.
+package cached
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/cache"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+// Repositories are the repositories a LocationService is built from.
+type Repositories struct {
+	Country        services.CountryRepository
+	Region         services.RegionRepository
+	District       services.DistrictRepository
+	Constituency   services.ConstituencyRepository
+	City           services.CityRepository
+	PollingStation services.PollingStationRepository
+	Search         services.SearchRepository
+	Lineage        services.LineageRepository
+	AlternateName  services.AlternateNameRepository
+	LocalizedName  services.LocalizedNameRepository
+	Meta           services.MetaRepository
+}
+
+// NewLocationService returns a LocationService whose repository reads go
+// through c. The meta repository is not cached, as it supplies the dataset
+// revision the cache keys depend on.
+func NewLocationService(repos Repositories, store cache.Store) (*services.LocationService, *cache.Cache) {
+	var service *services.LocationService
+	c := cache.New(store, func(ctx context.Context) (string, error) {
+		version, err := service.DatasetVersion(ctx)
+		if err != nil || version == nil {
+			return "", err
+		}
+		return version.Revision(), nil
+	})
+
+	service = services.NewLocationService(
+		&CountryRepository{next: repos.Country, cache: c},
+		&RegionRepository{next: repos.Region, cache: c},
+		&DistrictRepository{next: repos.District, cache: c},
+		&ConstituencyRepository{next: repos.Constituency, cache: c},
+		&CityRepository{next: repos.City, cache: c},
+		&PollingStationRepository{next: repos.PollingStation, cache: c},
+		&SearchRepository{next: repos.Search, cache: c},
+		&LineageRepository{next: repos.Lineage, cache: c},
+		&AlternateNameRepository{next: repos.AlternateName, cache: c},
+		&LocalizedNameRepository{next: repos.LocalizedName, cache: c},
+		repos.Meta,
+	)
+	return service, c
+}
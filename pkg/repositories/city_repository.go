@@ -1,45 +1,173 @@
-package repositories
-
-import (
-	"context"
-
-	"github.com/ghana-location-api/pkg/models"
-	"github.com/jackc/pgx/v5/pgxpool"
-)
-
-type CityRepository struct {
-	pool *pgxpool.Pool
-}
-
-func NewCityRepository(pool *pgxpool.Pool) *CityRepository {
-	return &CityRepository{pool: pool}
-}
-
-func (r *CityRepository) GetByDistrictSlug(ctx context.Context, districtSlug string) ([]models.City, error) {
-	rows, err := r.pool.Query(ctx, `
-		SELECT c.id, c.district_id, c.name, c.lat, c.lng
-		FROM cities c
-		JOIN districts d ON c.district_id = d.id
-		WHERE d.slug = $1
-		ORDER BY c.name
-	`, districtSlug)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var cities []models.City
-	for rows.Next() {
-		var city models.City
-		var lat, lng *float64
-		err := rows.Scan(&city.ID, &city.DistrictID, &city.Name, &lat, &lng)
-		if err != nil {
-			return nil, err
-		}
-		city.Lat = lat
-		city.Lng = lng
-		cities = append(cities, city)
-	}
-
-	return cities, rows.Err()
-}
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/geo"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CityRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCityRepository(pool *pgxpool.Pool) *CityRepository {
+	return &CityRepository{pool: pool}
+}
+
+func (r *CityRepository) GetBySlug(ctx context.Context, slug string) (*models.City, error) {
+	var city models.City
+	err := r.pool.QueryRow(ctx, "SELECT id, district_id, name, slug, lat, lng FROM cities WHERE slug = $1", slug).
+		Scan(&city.ID, &city.DistrictID, &city.Name, &city.Slug, &city.Lat, &city.Lng)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &city, nil
+}
+
+func (r *CityRepository) GetByDistrictSlug(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.City], error) {
+	return r.List(ctx, models.CityFilter{DistrictSlug: districtSlug}, opts)
+}
+
+func (r *CityRepository) List(ctx context.Context, filter models.CityFilter, opts models.ListOptions) (*models.List[models.City], error) {
+	var where conditions
+	region := where.districtRegion(ctx, "d")
+	if filter.RegionSlug != "" {
+		where.add("r.slug = $%d", filter.RegionSlug)
+	}
+	if filter.DistrictSlug != "" {
+		where.add("d.slug = $%d", filter.DistrictSlug)
+	}
+	if filter.HasCoordinates != nil {
+		if *filter.HasCoordinates {
+			where.add("c.lat IS NOT NULL AND c.lng IS NOT NULL")
+		} else {
+			where.add("(c.lat IS NULL OR c.lng IS NULL)")
+		}
+	}
+
+	return queryList(ctx, r.pool, listQuery[models.City]{
+		query: `
+			SELECT c.id, c.district_id, c.name, c.slug, c.lat, c.lng
+			FROM cities c
+			JOIN districts d ON c.district_id = d.id
+			JOIN regions r ON r.id = ` + region + where.sql(),
+		args:        where.args,
+		sortable:    map[string]string{"name": "name", "slug": "slug"},
+		defaultSort: "name",
+		scan:        scanCity,
+	}, opts)
+}
+
+// GetByDistrictIDs returns the cities of the given districts, keyed by district id.
+func (r *CityRepository) GetByDistrictIDs(ctx context.Context, districtIDs []string) (map[string][]models.City, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, district_id, name, slug, lat, lng
+		FROM cities
+		WHERE district_id = ANY($1)
+		ORDER BY name
+	`, districtIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cities := make(map[string][]models.City)
+	for rows.Next() {
+		city, err := scanCity(rows)
+		if err != nil {
+			return nil, err
+		}
+		cities[city.DistrictID] = append(cities[city.DistrictID], city)
+	}
+
+	return cities, rows.Err()
+}
+
+func scanCity(rows pgx.Rows, extra ...any) (models.City, error) {
+	var city models.City
+	err := rows.Scan(append([]any{&city.ID, &city.DistrictID, &city.Name, &city.Slug, &city.Lat, &city.Lng}, extra...)...)
+	return city, err
+}
+
+const (
+	nearestStartRadius = 10.0
+	nearestMaxRadiusKm = 1000.0
+)
+
+// haversineSQL computes the great-circle distance in km between the point
+// ($1, $2) and the row's c.lat/c.lng.
+const haversineSQL = `6371.0 * 2 * asin(sqrt(
+	power(sin(radians(c.lat::float8 - $1::float8) / 2), 2) +
+	cos(radians($1::float8)) * cos(radians(c.lat::float8)) * power(sin(radians(c.lng::float8 - $2::float8) / 2), 2)
+))`
+
+// GetNearest returns up to limit cities closest to the given point, ordered by
+// great-circle distance. It searches within a radius of the point and doubles
+// the radius until enough cities are found.
+func (r *CityRepository) GetNearest(ctx context.Context, lat, lng float64, limit int) ([]models.NearestCity, error) {
+	var results []models.NearestCity
+	for radius := nearestStartRadius; radius <= nearestMaxRadiusKm; radius *= 2 {
+		var err error
+		results, err = r.GetWithinRadius(ctx, lat, lng, radius, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) >= limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// GetWithinRadius returns up to limit cities within radiusKm of the given
+// point, ordered by great-circle distance. Candidates are taken from the
+// bounding box of the radius, which idx_cities_location serves.
+func (r *CityRepository) GetWithinRadius(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]models.NearestCity, error) {
+	minLat, maxLat, minLng, maxLng := geo.BoundingBox(lat, lng, radiusKm)
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT * FROM (
+			SELECT c.id, c.district_id, c.name, c.slug, c.lat, c.lng,
+				d.id, r.id, d.name, d.slug, d.type, d.capital, d.valid_from, d.valid_to,
+				r.id, r.country_id, r.name, r.slug, r.capital, r.valid_from, r.valid_to,
+				`+haversineSQL+` AS distance_km
+			FROM cities c
+			JOIN districts d ON c.district_id = d.id
+			JOIN regions r ON r.id = `+districtRegionSQL("d", 9)+`
+			WHERE c.lat IS NOT NULL AND c.lng IS NOT NULL
+			  AND point(c.lng::float8, c.lat::float8) <@ box(point($5::float8, $3::float8), point($6::float8, $4::float8))
+		) nearby
+		WHERE distance_km <= $7
+		ORDER BY distance_km
+		LIMIT $8
+	`, lat, lng, minLat, maxLat, minLng, maxLng, radiusKm, limit, models.ValidityDate(ctx).Time)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []models.NearestCity{}
+	for rows.Next() {
+		var result models.NearestCity
+		city, district, region := &result.City, &result.District, &result.Region
+		err := rows.Scan(
+			&city.ID, &city.DistrictID, &city.Name, &city.Slug, &city.Lat, &city.Lng,
+			&district.ID, &district.RegionID, &district.Name, &district.Slug, &district.Type, &district.Capital, &district.ValidFrom, &district.ValidTo,
+			&region.ID, &region.CountryID, &region.Name, &region.Slug, &region.Capital, &region.ValidFrom, &region.ValidTo,
+			&result.DistanceKm,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result.Constituencies = []models.Constituency{}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
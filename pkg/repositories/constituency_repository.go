@@ -1,55 +1,164 @@
-package repositories
-
-import (
-	"context"
-
-	"github.com/ghana-location-api/pkg/models"
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
-)
-
-type ConstituencyRepository struct {
-	pool *pgxpool.Pool
-}
-
-func NewConstituencyRepository(pool *pgxpool.Pool) *ConstituencyRepository {
-	return &ConstituencyRepository{pool: pool}
-}
-
-func (r *ConstituencyRepository) GetBySlug(ctx context.Context, slug string) (*models.Constituency, error) {
-	var constituency models.Constituency
-	err := r.pool.QueryRow(ctx, "SELECT id, district_id, name, slug FROM constituencies WHERE slug = $1", slug).
-		Scan(&constituency.ID, &constituency.DistrictID, &constituency.Name, &constituency.Slug)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
-	}
-	return &constituency, nil
-}
-
-func (r *ConstituencyRepository) GetByDistrictSlug(ctx context.Context, districtSlug string) ([]models.Constituency, error) {
-	rows, err := r.pool.Query(ctx, `
-		SELECT c.id, c.district_id, c.name, c.slug
-		FROM constituencies c
-		JOIN districts d ON c.district_id = d.id
-		WHERE d.slug = $1
-		ORDER BY c.name
-	`, districtSlug)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var constituencies []models.Constituency
-	for rows.Next() {
-		var constituency models.Constituency
-		if err := rows.Scan(&constituency.ID, &constituency.DistrictID, &constituency.Name, &constituency.Slug); err != nil {
-			return nil, err
-		}
-		constituencies = append(constituencies, constituency)
-	}
-
-	return constituencies, rows.Err()
-}
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ConstituencyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewConstituencyRepository(pool *pgxpool.Pool) *ConstituencyRepository {
+	return &ConstituencyRepository{pool: pool}
+}
+
+// constituencyColumns are the columns scanned into a models.Constituency, in order.
+const constituencyColumns = "id, district_id, region_id, name, slug, valid_from, valid_to"
+
+func (r *ConstituencyRepository) GetBySlug(ctx context.Context, slug string) (*models.Constituency, error) {
+	var where conditions
+	where.add("slug = $%d", slug)
+	where.asOf(ctx, "constituencies")
+
+	var constituency models.Constituency
+	err := r.pool.QueryRow(ctx, "SELECT "+constituencyColumns+" FROM constituencies"+where.sql(), where.args...).
+		Scan(&constituency.ID, &constituency.DistrictID, &constituency.RegionID, &constituency.Name, &constituency.Slug, &constituency.ValidFrom, &constituency.ValidTo)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &constituency, nil
+}
+
+func (r *ConstituencyRepository) GetByID(ctx context.Context, id string) (*models.Constituency, error) {
+	var constituency models.Constituency
+	err := r.pool.QueryRow(ctx, "SELECT "+constituencyColumns+" FROM constituencies WHERE id = $1", id).
+		Scan(&constituency.ID, &constituency.DistrictID, &constituency.RegionID, &constituency.Name, &constituency.Slug, &constituency.ValidFrom, &constituency.ValidTo)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &constituency, nil
+}
+
+// GetByECNumber returns the constituency of a region with the given number
+// in polling station codes. A constituency that was split shares its number
+// with the constituencies split from it; nil is returned when the number
+// does not name a single constituency.
+func (r *ConstituencyRepository) GetByECNumber(ctx context.Context, regionID string, number int) (*models.Constituency, error) {
+	where := conditions{args: []any{regionID, number}}
+	where.add("region_id = $1 AND ec_number = $2")
+	where.validOn(ctx, "constituencies")
+
+	rows, err := r.pool.Query(ctx, "SELECT "+constituencyColumns+" FROM constituencies"+where.sql()+" LIMIT 2", where.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []models.Constituency
+	for rows.Next() {
+		var constituency models.Constituency
+		if err := rows.Scan(&constituency.ID, &constituency.DistrictID, &constituency.RegionID, &constituency.Name, &constituency.Slug, &constituency.ValidFrom, &constituency.ValidTo); err != nil {
+			return nil, err
+		}
+		found = append(found, constituency)
+	}
+	if err := rows.Err(); err != nil || len(found) != 1 {
+		return nil, err
+	}
+	return &found[0], nil
+}
+
+func (r *ConstituencyRepository) GetByDistrictSlug(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	return r.List(ctx, models.ConstituencyFilter{DistrictSlug: districtSlug}, opts)
+}
+
+func (r *ConstituencyRepository) GetByRegionSlug(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	return r.List(ctx, models.ConstituencyFilter{RegionSlug: regionSlug}, opts)
+}
+
+func (r *ConstituencyRepository) List(ctx context.Context, filter models.ConstituencyFilter, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	var where conditions
+	where.validOn(ctx, "c")
+	if filter.RegionSlug != "" {
+		where.add("r.slug = $%d", filter.RegionSlug)
+	}
+	if filter.DistrictSlug != "" {
+		where.add("d.slug = $%d", filter.DistrictSlug)
+	}
+
+	return queryList(ctx, r.pool, listQuery[models.Constituency]{
+		query: `
+			SELECT c.id, c.district_id, c.region_id, c.name, c.slug, c.valid_from, c.valid_to
+			FROM constituencies c
+			LEFT JOIN districts d ON c.district_id = d.id
+			LEFT JOIN regions r ON c.region_id = r.id` + where.sql(),
+		args:        where.args,
+		sortable:    map[string]string{"name": "name", "slug": "slug"},
+		defaultSort: "name",
+		scan:        scanConstituency,
+	}, opts)
+}
+
+func scanConstituency(rows pgx.Rows, extra ...any) (models.Constituency, error) {
+	var constituency models.Constituency
+	err := rows.Scan(append([]any{&constituency.ID, &constituency.DistrictID, &constituency.RegionID, &constituency.Name, &constituency.Slug, &constituency.ValidFrom, &constituency.ValidTo}, extra...)...)
+	return constituency, err
+}
+
+func (r *ConstituencyRepository) GetByDistrictIDs(ctx context.Context, districtIDs []string) (map[string][]models.Constituency, error) {
+	where := conditions{args: []any{districtIDs}}
+	where.add("district_id = ANY($1)")
+	where.validOn(ctx, "constituencies")
+
+	rows, err := r.pool.Query(ctx, "SELECT "+constituencyColumns+" FROM constituencies"+where.sql()+" ORDER BY name", where.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	constituencies := make(map[string][]models.Constituency)
+	for rows.Next() {
+		constituency, err := scanConstituency(rows)
+		if err != nil {
+			return nil, err
+		}
+		constituencies[*constituency.DistrictID] = append(constituencies[*constituency.DistrictID], constituency)
+	}
+
+	return constituencies, rows.Err()
+}
+
+// GetWithoutDistrictByRegionIDs returns the constituencies of the given
+// regions that are not linked to a district, keyed by region id.
+func (r *ConstituencyRepository) GetWithoutDistrictByRegionIDs(ctx context.Context, regionIDs []string) (map[string][]models.Constituency, error) {
+	where := conditions{args: []any{regionIDs}}
+	where.add("region_id = ANY($1) AND district_id IS NULL")
+	where.validOn(ctx, "constituencies")
+
+	rows, err := r.pool.Query(ctx, "SELECT "+constituencyColumns+" FROM constituencies"+where.sql()+" ORDER BY name", where.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	constituencies := make(map[string][]models.Constituency)
+	for rows.Next() {
+		constituency, err := scanConstituency(rows)
+		if err != nil {
+			return nil, err
+		}
+		constituencies[*constituency.RegionID] = append(constituencies[*constituency.RegionID], constituency)
+	}
+
+	return constituencies, rows.Err()
+}
@@ -1,55 +1,154 @@
-package repositories
-
-import (
-	"context"
-
-	"github.com/ghana-location-api/pkg/models"
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
-)
-
-type DistrictRepository struct {
-	pool *pgxpool.Pool
-}
-
-func NewDistrictRepository(pool *pgxpool.Pool) *DistrictRepository {
-	return &DistrictRepository{pool: pool}
-}
-
-func (r *DistrictRepository) GetBySlug(ctx context.Context, slug string) (*models.District, error) {
-	var district models.District
-	err := r.pool.QueryRow(ctx, "SELECT id, region_id, name, slug, type, capital FROM districts WHERE slug = $1", slug).
-		Scan(&district.ID, &district.RegionID, &district.Name, &district.Slug, &district.Type, &district.Capital)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
-	}
-	return &district, nil
-}
-
-func (r *DistrictRepository) GetByRegionSlug(ctx context.Context, regionSlug string) ([]models.District, error) {
-	rows, err := r.pool.Query(ctx, `
-		SELECT d.id, d.region_id, d.name, d.slug, d.type, d.capital 
-		FROM districts d
-		JOIN regions r ON d.region_id = r.id
-		WHERE r.slug = $1
-		ORDER BY d.name
-	`, regionSlug)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var districts []models.District
-	for rows.Next() {
-		var district models.District
-		if err := rows.Scan(&district.ID, &district.RegionID, &district.Name, &district.Slug, &district.Type, &district.Capital); err != nil {
-			return nil, err
-		}
-		districts = append(districts, district)
-	}
-
-	return districts, rows.Err()
-}
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DistrictRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewDistrictRepository(pool *pgxpool.Pool) *DistrictRepository {
+	return &DistrictRepository{pool: pool}
+}
+
+// districtColumns returns the columns of district alias scanned into a
+// models.District, in order, with region in place of its region_id.
+func districtColumns(alias, region string) string {
+	return fmt.Sprintf("%[1]s.id, %[2]s, %[1]s.name, %[1]s.slug, %[1]s.type, %[1]s.capital, %[1]s.valid_from, %[1]s.valid_to", alias, region)
+}
+
+func (r *DistrictRepository) GetBySlug(ctx context.Context, slug string) (*models.District, error) {
+	var where conditions
+	where.add("slug = $%d", slug)
+	where.asOf(ctx, "districts")
+	region := where.districtRegion(ctx, "districts")
+
+	var district models.District
+	err := r.pool.QueryRow(ctx, "SELECT "+districtColumns("districts", region)+" FROM districts"+where.sql(), where.args...).
+		Scan(&district.ID, &district.RegionID, &district.Name, &district.Slug, &district.Type, &district.Capital, &district.ValidFrom, &district.ValidTo)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &district, nil
+}
+
+func (r *DistrictRepository) GetByID(ctx context.Context, id string) (*models.District, error) {
+	where := conditions{args: []any{id}}
+	where.add("id = $1")
+	region := where.districtRegion(ctx, "districts")
+
+	var district models.District
+	err := r.pool.QueryRow(ctx, "SELECT "+districtColumns("districts", region)+" FROM districts"+where.sql(), where.args...).
+		Scan(&district.ID, &district.RegionID, &district.Name, &district.Slug, &district.Type, &district.Capital, &district.ValidFrom, &district.ValidTo)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &district, nil
+}
+
+func (r *DistrictRepository) GetByRegionSlug(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.District], error) {
+	return r.List(ctx, models.DistrictFilter{RegionSlug: regionSlug}, opts)
+}
+
+func (r *DistrictRepository) List(ctx context.Context, filter models.DistrictFilter, opts models.ListOptions) (*models.List[models.District], error) {
+	var where conditions
+	where.validOn(ctx, "d")
+	region := where.districtRegion(ctx, "d")
+	if filter.RegionSlug != "" {
+		where.add("r.slug = $%d", filter.RegionSlug)
+	}
+	if filter.Type != "" {
+		where.add("d.type = $%d", filter.Type)
+	}
+
+	return queryList(ctx, r.pool, listQuery[models.District]{
+		query: `
+			SELECT ` + districtColumns("d", region) + `
+			FROM districts d
+			JOIN regions r ON r.id = ` + region + where.sql(),
+		args:        where.args,
+		sortable:    map[string]string{"name": "name", "slug": "slug", "type": "type"},
+		defaultSort: "name",
+		scan:        scanDistrict,
+	}, opts)
+}
+
+// GetByRegionIDs returns the districts of the given regions, keyed by region id.
+func (r *DistrictRepository) GetByRegionIDs(ctx context.Context, regionIDs []string) (map[string][]models.District, error) {
+	where := conditions{args: []any{regionIDs}}
+	region := where.districtRegion(ctx, "districts")
+	where.add(region + " = ANY($1)")
+	where.validOn(ctx, "districts")
+
+	rows, err := r.pool.Query(ctx, "SELECT "+districtColumns("districts", region)+" FROM districts"+where.sql()+" ORDER BY name", where.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	districts := make(map[string][]models.District)
+	for rows.Next() {
+		district, err := scanDistrict(rows)
+		if err != nil {
+			return nil, err
+		}
+		districts[district.RegionID] = append(districts[district.RegionID], district)
+	}
+
+	return districts, rows.Err()
+}
+
+func scanDistrict(rows pgx.Rows, extra ...any) (models.District, error) {
+	var district models.District
+	err := rows.Scan(append([]any{&district.ID, &district.RegionID, &district.Name, &district.Slug, &district.Type, &district.Capital, &district.ValidFrom, &district.ValidTo}, extra...)...)
+	return district, err
+}
+
+func (r *DistrictRepository) GetBoundary(ctx context.Context, slug string) (*models.Boundary, error) {
+	boundary := models.Boundary{Type: "Feature", Properties: models.BoundaryProperties{Type: "district"}}
+	var where conditions
+	where.add("slug = $%d", slug)
+	where.add("boundary IS NOT NULL")
+	where.asOf(ctx, "districts")
+
+	var geometry string
+	err := r.pool.QueryRow(ctx, "SELECT name, slug, ST_AsGeoJSON(boundary) FROM districts"+where.sql(), where.args...).
+		Scan(&boundary.Properties.Name, &boundary.Properties.Slug, &geometry)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	boundary.Geometry = []byte(geometry)
+	return &boundary, nil
+}
+
+func (r *DistrictRepository) GetContaining(ctx context.Context, lat, lng float64) (*models.District, error) {
+	where := conditions{args: []any{lat, lng}}
+	where.add("ST_Contains(boundary, ST_SetSRID(ST_MakePoint($2, $1), 4326))")
+	where.validOn(ctx, "districts")
+	region := where.districtRegion(ctx, "districts")
+
+	var district models.District
+	err := r.pool.QueryRow(ctx, "SELECT "+districtColumns("districts", region)+" FROM districts"+where.sql()+" LIMIT 1", where.args...).Scan(&district.ID, &district.RegionID, &district.Name, &district.Slug, &district.Type, &district.Capital, &district.ValidFrom, &district.ValidTo)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &district, nil
+}
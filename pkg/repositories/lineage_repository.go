@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lineageTables maps the kinds of record in the lineage table to their tables.
+var lineageTables = map[string]string{
+	"district":     "districts",
+	"constituency": "constituencies",
+}
+
+type LineageRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewLineageRepository(pool *pgxpool.Pool) *LineageRepository {
+	return &LineageRepository{pool: pool}
+}
+
+func (r *LineageRepository) GetPredecessors(ctx context.Context, kind, slug string) ([]models.LineageLink, error) {
+	return r.links(ctx, kind, "successor_slug", "predecessor_slug", slug)
+}
+
+func (r *LineageRepository) GetSuccessors(ctx context.Context, kind, slug string) ([]models.LineageLink, error) {
+	return r.links(ctx, kind, "predecessor_slug", "successor_slug", slug)
+}
+
+// links returns the records linked to slug, matching slug against the from
+// column and reading the linked records from the to column.
+func (r *LineageRepository) links(ctx context.Context, kind, from, to, slug string) ([]models.LineageLink, error) {
+	table, ok := lineageTables[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown lineage kind %q", kind)
+	}
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT l.type, l.effective_date, l.%[3]s, t.name
+		FROM lineage l
+		JOIN %[1]s t ON t.slug = l.%[3]s
+		WHERE l.kind = $1 AND l.%[2]s = $2
+		ORDER BY l.effective_date, t.name`, table, from, to), kind, slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []models.LineageLink{}
+	for rows.Next() {
+		var link models.LineageLink
+		if err := rows.Scan(&link.Type, &link.Date, &link.Slug, &link.Name); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
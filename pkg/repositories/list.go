@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	DefaultListLimit = 100
+	MaxListLimit     = 1000
+)
+
+// listQuery describes a list endpoint's result set. The query must return an
+// id column plus every column named in sortable, with no duplicate column names.
+type listQuery[T any] struct {
+	query       string
+	args        []any
+	sortable    map[string]string // sort field -> column in query
+	defaultSort string
+	// scan reads one row into T, passing extra as the trailing scan targets.
+	scan func(rows pgx.Rows, extra ...any) (T, error)
+}
+
+// ListCursor identifies the last row of a page: its sort value and id.
+type ListCursor struct {
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// queryList runs a listQuery with keyset pagination: rows are ordered by the
+// sort column and id, and the cursor holds the last row's values so the next
+// page starts right after it.
+func queryList[T any](ctx context.Context, pool *pgxpool.Pool, q listQuery[T], opts models.ListOptions) (*models.List[T], error) {
+	limit := ListLimit(opts)
+	field, descending := ParseSort(opts.Sort, q.defaultSort)
+	column, ok := q.sortable[field]
+	if !ok {
+		return nil, errors.ErrInvalidSort
+	}
+	order, comparison := "ASC", ">"
+	if descending {
+		order, comparison = "DESC", "<"
+	}
+
+	list := &models.List[T]{Data: []T{}}
+	if err := pool.QueryRow(ctx, "SELECT count(*) FROM ("+q.query+") t", q.args...).Scan(&list.Total); err != nil {
+		return nil, err
+	}
+
+	args := append([]any{}, q.args...)
+	where := ""
+	if opts.Cursor != "" {
+		cursor, err := DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, errors.ErrInvalidCursor
+		}
+		args = append(args, cursor.Value, cursor.ID)
+		where = fmt.Sprintf("WHERE (t.%s, t.id) %s ($%d, $%d::uuid)", column, comparison, len(args)-1, len(args))
+	}
+	args = append(args, limit+1)
+
+	rows, err := pool.Query(ctx, fmt.Sprintf(
+		"SELECT t.*, t.%[1]s::text, t.id::text FROM (%[2]s) t %[3]s ORDER BY t.%[1]s %[4]s, t.id %[4]s LIMIT $%[5]d",
+		column, q.query, where, order, len(args),
+	), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var last ListCursor
+	for rows.Next() {
+		if len(list.Data) == limit {
+			next := EncodeCursor(last)
+			list.NextCursor = &next
+			break
+		}
+		var cursor ListCursor
+		item, err := q.scan(rows, &cursor.Value, &cursor.ID)
+		if err != nil {
+			return nil, err
+		}
+		list.Data = append(list.Data, item)
+		last = cursor
+	}
+
+	return list, rows.Err()
+}
+
+// conditions accumulates the WHERE clause of a filtered list query.
+type conditions struct {
+	clauses []string
+	args    []any
+}
+
+// add appends a condition. When an argument is given, the condition must
+// contain a single %d verb, or %[1]d verbs, which are replaced by the
+// argument's placeholder number.
+func (c *conditions) add(condition string, arg ...any) {
+	if len(arg) > 0 {
+		c.args = append(c.args, arg[0])
+		condition = fmt.Sprintf(condition, len(c.args))
+	}
+	c.clauses = append(c.clauses, condition)
+}
+
+func (c *conditions) sql() string {
+	if len(c.clauses) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.clauses, " AND ")
+}
+
+// ListLimit returns the page size for opts, applying the default and maximum.
+func ListLimit(opts models.ListOptions) int {
+	if opts.Limit <= 0 {
+		return DefaultListLimit
+	}
+	if opts.Limit > MaxListLimit {
+		return MaxListLimit
+	}
+	return opts.Limit
+}
+
+// ParseSort splits a sort parameter such as "-name" into the field and
+// whether the order is descending, falling back to defaultField.
+func ParseSort(sort, defaultField string) (string, bool) {
+	field, descending := strings.CutPrefix(sort, "-")
+	if field == "" {
+		field = defaultField
+	}
+	return field, descending
+}
+
+func EncodeCursor(cursor ListCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(encoded string) (ListCursor, error) {
+	var cursor ListCursor
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, err
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, err
+	}
+	var id pgtype.UUID
+	if err := id.Scan(cursor.ID); err != nil {
+		return cursor, err
+	}
+	return cursor, nil
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursors := []ListCursor{
+		{Value: "Kumasi", ID: "0b5c3a4e-6d7f-4a8b-9c0d-1e2f3a4b5c6d"},
+		{Value: "", ID: "00000000-0000-0000-0000-000000000000"},
+		{Value: "Ɔdɔmase, \"Nkwanta\" / 100%_", ID: "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+	}
+	for _, cursor := range cursors {
+		encoded := EncodeCursor(cursor)
+		got, err := DecodeCursor(encoded)
+		if err != nil {
+			t.Errorf("DecodeCursor(EncodeCursor(%+v)) error: %v", cursor, err)
+			continue
+		}
+		if got != cursor {
+			t.Errorf("DecodeCursor(EncodeCursor(%+v)) = %+v", cursor, got)
+		}
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	cursors := map[string]string{
+		"empty":           "",
+		"not base64":      "not a cursor!",
+		"padded base64":   base64.URLEncoding.EncodeToString([]byte(`{"v":"a","id":"0b5c3a4e-6d7f-4a8b-9c0d-1e2f3a4b5c6d"}`)),
+		"standard base64": base64.RawStdEncoding.EncodeToString([]byte(`{"v":"??>>","id":"0b5c3a4e-6d7f-4a8b-9c0d-1e2f3a4b5c6d"}`)),
+		"not JSON":        encode("kumasi"),
+		"JSON array":      encode(`["a","0b5c3a4e-6d7f-4a8b-9c0d-1e2f3a4b5c6d"]`),
+		"no id":           encode(`{"v":"a"}`),
+		"id not a UUID":   encode(`{"v":"a","id":"42"}`),
+		"id not a string": encode(`{"v":"a","id":42}`),
+		"value not text":  encode(`{"v":1,"id":"0b5c3a4e-6d7f-4a8b-9c0d-1e2f3a4b5c6d"}`),
+	}
+	for name, encoded := range cursors {
+		if cursor, err := DecodeCursor(encoded); err == nil {
+			t.Errorf("%s: DecodeCursor(%q) = %+v, want an error", name, encoded, cursor)
+		}
+	}
+}
+
+func TestListLimit(t *testing.T) {
+	tests := []struct {
+		limit, want int
+	}{
+		{0, DefaultListLimit},
+		{-1, DefaultListLimit},
+		{1, 1},
+		{MaxListLimit, MaxListLimit},
+		{MaxListLimit + 1, MaxListLimit},
+	}
+	for _, tt := range tests {
+		if got := ListLimit(models.ListOptions{Limit: tt.limit}); got != tt.want {
+			t.Errorf("ListLimit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		sort       string
+		field      string
+		descending bool
+	}{
+		{"", "name", false},
+		{"-", "name", true},
+		{"population", "population", false},
+		{"-population", "population", true},
+		{"--name", "-name", true},
+	}
+	for _, tt := range tests {
+		field, descending := ParseSort(tt.sort, "name")
+		if field != tt.field || descending != tt.descending {
+			t.Errorf("ParseSort(%q) = %q, %v, want %q, %v", tt.sort, field, descending, tt.field, tt.descending)
+		}
+	}
+}
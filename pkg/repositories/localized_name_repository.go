@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LocalizedNameRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewLocalizedNameRepository(pool *pgxpool.Pool) *LocalizedNameRepository {
+	return &LocalizedNameRepository{pool: pool}
+}
+
+func (r *LocalizedNameRepository) GetByLanguage(ctx context.Context, lang string) ([]models.LocalizedName, error) {
+	rows, err := r.pool.Query(ctx, "SELECT kind, slug, lang, name FROM localized_names WHERE lang = $1 ORDER BY kind, slug", lang)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []models.LocalizedName{}
+	for rows.Next() {
+		var name models.LocalizedName
+		if err := rows.Scan(&name.Kind, &name.Slug, &name.Lang, &name.Name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+func (r *LocalizedNameRepository) GetLanguages(ctx context.Context) ([]string, error) {
+	rows, err := r.pool.Query(ctx, "SELECT DISTINCT lang FROM localized_names ORDER BY lang")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	languages := []string{}
+	for rows.Next() {
+		var lang string
+		if err := rows.Scan(&lang); err != nil {
+			return nil, err
+		}
+		languages = append(languages, lang)
+	}
+	return languages, rows.Err()
+}
@@ -0,0 +1,22 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+type AlternateNameRepository struct {
+	store *Store
+}
+
+func NewAlternateNameRepository(store *Store) *AlternateNameRepository {
+	return &AlternateNameRepository{store: store}
+}
+
+func (r *AlternateNameRepository) GetByKey(ctx context.Context, kind, key string) (*models.AlternateName, error) {
+	if name, ok := r.store.alternateNames[alternateNameKey(kind, key)]; ok {
+		return &name, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/ghana-location-api/pkg/geo"
+	"github.com/ghana-location-api/pkg/models"
+)
+
+type CityRepository struct {
+	store *Store
+}
+
+func NewCityRepository(store *Store) *CityRepository {
+	return &CityRepository{store: store}
+}
+
+func (r *CityRepository) GetBySlug(ctx context.Context, slug string) (*models.City, error) {
+	if i, ok := r.store.cityBySlug[slug]; ok {
+		city := r.store.cities[i]
+		return &city, nil
+	}
+	return nil, nil
+}
+
+func (r *CityRepository) GetByDistrictSlug(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.City], error) {
+	return r.List(ctx, models.CityFilter{DistrictSlug: districtSlug}, opts)
+}
+
+func (r *CityRepository) List(ctx context.Context, f models.CityFilter, opts models.ListOptions) (*models.List[models.City], error) {
+	date := models.ValidityDate(ctx)
+	cities := filter(r.store.cities, func(c models.City) bool {
+		if f.DistrictSlug != "" && r.store.district(c.DistrictID).Slug != f.DistrictSlug {
+			return false
+		}
+		if f.RegionSlug != "" && r.store.districtRegionSlug(c.DistrictID, date) != f.RegionSlug {
+			return false
+		}
+		return f.HasCoordinates == nil || *f.HasCoordinates == (c.Lat != nil && c.Lng != nil)
+	})
+
+	return queryList(listQuery[models.City]{
+		items: cities,
+		sortable: map[string]func(models.City) string{
+			"name": func(c models.City) string { return c.Name },
+			"slug": func(c models.City) string { return c.Slug },
+		},
+		defaultSort: "name",
+		id:          func(c models.City) string { return c.ID },
+	}, opts)
+}
+
+func (r *CityRepository) GetByDistrictIDs(ctx context.Context, districtIDs []string) (map[string][]models.City, error) {
+	wanted := make(map[string]bool, len(districtIDs))
+	for _, id := range districtIDs {
+		wanted[id] = true
+	}
+
+	sorted := slices.Clone(r.store.cities)
+	slices.SortFunc(sorted, func(a, b models.City) int { return cmp.Compare(a.Name, b.Name) })
+
+	cities := make(map[string][]models.City)
+	for _, c := range sorted {
+		if wanted[c.DistrictID] {
+			cities[c.DistrictID] = append(cities[c.DistrictID], c)
+		}
+	}
+	return cities, nil
+}
+
+// GetNearest returns up to limit cities closest to the given point, ordered by
+// great-circle distance.
+func (r *CityRepository) GetNearest(ctx context.Context, lat, lng float64, limit int) ([]models.NearestCity, error) {
+	date := models.ValidityDate(ctx)
+	results := []models.NearestCity{}
+	for _, i := range r.store.citiesByLat {
+		results = append(results, r.nearestCity(r.store.cities[i], lat, lng, date))
+	}
+	return sortNearest(results, limit), nil
+}
+
+// GetWithinRadius returns up to limit cities within radiusKm of the given
+// point, ordered by great-circle distance. Only the cities in the latitude
+// band of the radius are scanned.
+func (r *CityRepository) GetWithinRadius(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]models.NearestCity, error) {
+	minLat, maxLat, minLng, maxLng := geo.BoundingBox(lat, lng, radiusKm)
+	cities := r.store.cities
+	start, _ := slices.BinarySearchFunc(r.store.citiesByLat, minLat, func(i int, lat float64) int { return cmp.Compare(*cities[i].Lat, lat) })
+
+	date := models.ValidityDate(ctx)
+	results := []models.NearestCity{}
+	for _, i := range r.store.citiesByLat[start:] {
+		city := cities[i]
+		if *city.Lat > maxLat {
+			break
+		}
+		if *city.Lng < minLng || *city.Lng > maxLng {
+			continue
+		}
+		if result := r.nearestCity(city, lat, lng, date); result.DistanceKm <= radiusKm {
+			results = append(results, result)
+		}
+	}
+	return sortNearest(results, limit), nil
+}
+
+// nearestCity returns city with its distance from the given point and the
+// district and region it was in on date.
+func (r *CityRepository) nearestCity(city models.City, lat, lng float64, date models.Date) models.NearestCity {
+	district := r.store.districtOn(*r.store.district(city.DistrictID), date)
+	return models.NearestCity{
+		City:           city,
+		DistanceKm:     geo.HaversineKm(lat, lng, *city.Lat, *city.Lng),
+		District:       district,
+		Region:         *r.store.region(district.RegionID),
+		Constituencies: []models.Constituency{},
+	}
+}
+
+func sortNearest(results []models.NearestCity, limit int) []models.NearestCity {
+	slices.SortFunc(results, func(a, b models.NearestCity) int { return cmp.Compare(a.DistanceKm, b.DistanceKm) })
+	return results[:min(limit, len(results))]
+}
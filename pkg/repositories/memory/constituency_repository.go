@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+type ConstituencyRepository struct {
+	store *Store
+}
+
+func NewConstituencyRepository(store *Store) *ConstituencyRepository {
+	return &ConstituencyRepository{store: store}
+}
+
+func (r *ConstituencyRepository) GetBySlug(ctx context.Context, slug string) (*models.Constituency, error) {
+	if i, ok := r.store.constituencyBySlug[slug]; ok && asOf(ctx, r.store.constituencies[i]) {
+		constituency := r.store.constituencies[i]
+		return &constituency, nil
+	}
+	return nil, nil
+}
+
+func (r *ConstituencyRepository) GetByID(ctx context.Context, id string) (*models.Constituency, error) {
+	if i, ok := r.store.constituencyByID[id]; ok {
+		constituency := r.store.constituencies[i]
+		return &constituency, nil
+	}
+	return nil, nil
+}
+
+func (r *ConstituencyRepository) GetByECNumber(ctx context.Context, regionID string, number int) (*models.Constituency, error) {
+	var found *models.Constituency
+	for _, i := range r.store.constituenciesByECNumber[ecNumberKey(regionID, number)] {
+		if !r.store.constituencies[i].ValidOn(models.ValidityDate(ctx)) {
+			continue
+		}
+		if found != nil {
+			return nil, nil
+		}
+		constituency := r.store.constituencies[i]
+		found = &constituency
+	}
+	return found, nil
+}
+
+func (r *ConstituencyRepository) GetByDistrictSlug(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	return r.List(ctx, models.ConstituencyFilter{DistrictSlug: districtSlug}, opts)
+}
+
+func (r *ConstituencyRepository) GetByRegionSlug(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	return r.List(ctx, models.ConstituencyFilter{RegionSlug: regionSlug}, opts)
+}
+
+func (r *ConstituencyRepository) GetByDistrictIDs(ctx context.Context, districtIDs []string) (map[string][]models.Constituency, error) {
+	wanted := make(map[string]bool, len(districtIDs))
+	for _, id := range districtIDs {
+		wanted[id] = true
+	}
+
+	constituencies := make(map[string][]models.Constituency)
+	for _, c := range sortedByName(validOn(ctx, r.store.constituencies)) {
+		if c.DistrictID != nil && wanted[*c.DistrictID] {
+			constituencies[*c.DistrictID] = append(constituencies[*c.DistrictID], c)
+		}
+	}
+	return constituencies, nil
+}
+
+func (r *ConstituencyRepository) GetWithoutDistrictByRegionIDs(ctx context.Context, regionIDs []string) (map[string][]models.Constituency, error) {
+	wanted := make(map[string]bool, len(regionIDs))
+	for _, id := range regionIDs {
+		wanted[id] = true
+	}
+
+	constituencies := make(map[string][]models.Constituency)
+	for _, c := range sortedByName(validOn(ctx, r.store.constituencies)) {
+		if c.DistrictID == nil && c.RegionID != nil && wanted[*c.RegionID] {
+			constituencies[*c.RegionID] = append(constituencies[*c.RegionID], c)
+		}
+	}
+	return constituencies, nil
+}
+
+func (r *ConstituencyRepository) List(ctx context.Context, f models.ConstituencyFilter, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	constituencies := filter(validOn(ctx, r.store.constituencies), func(c models.Constituency) bool {
+		if f.DistrictSlug != "" && (c.DistrictID == nil || r.store.district(*c.DistrictID).Slug != f.DistrictSlug) {
+			return false
+		}
+		return f.RegionSlug == "" || (c.RegionID != nil && r.store.region(*c.RegionID).Slug == f.RegionSlug)
+	})
+
+	return queryList(listQuery[models.Constituency]{
+		items: constituencies,
+		sortable: map[string]func(models.Constituency) string{
+			"name": func(c models.Constituency) string { return c.Name },
+			"slug": func(c models.Constituency) string { return c.Slug },
+		},
+		defaultSort: "name",
+		id:          func(c models.Constituency) string { return c.ID },
+	}, opts)
+}
+
+func sortedByName(constituencies []models.Constituency) []models.Constituency {
+	sorted := slices.Clone(constituencies)
+	slices.SortFunc(sorted, func(a, b models.Constituency) int { return cmp.Compare(a.Name, b.Name) })
+	return sorted
+}
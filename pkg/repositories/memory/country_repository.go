@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+type CountryRepository struct {
+	store *Store
+}
+
+func NewCountryRepository(store *Store) *CountryRepository {
+	return &CountryRepository{store: store}
+}
+
+func (r *CountryRepository) GetAll(ctx context.Context, opts models.ListOptions) (*models.List[models.Country], error) {
+	return queryList(listQuery[models.Country]{
+		items: r.store.countries,
+		sortable: map[string]func(models.Country) string{
+			"name": func(c models.Country) string { return c.Name },
+			"code": func(c models.Country) string { return c.Code },
+		},
+		defaultSort: "name",
+		id:          func(c models.Country) string { return c.ID },
+	}, opts)
+}
+
+func (r *CountryRepository) GetByCode(ctx context.Context, code string) (*models.Country, error) {
+	if i, ok := r.store.countryByCode[code]; ok {
+		country := r.store.countries[i]
+		return &country, nil
+	}
+	return nil, nil
+}
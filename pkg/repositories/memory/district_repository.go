@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+type DistrictRepository struct {
+	store *Store
+}
+
+func NewDistrictRepository(store *Store) *DistrictRepository {
+	return &DistrictRepository{store: store}
+}
+
+func (r *DistrictRepository) GetBySlug(ctx context.Context, slug string) (*models.District, error) {
+	if i, ok := r.store.districtBySlug[slug]; ok && asOf(ctx, r.store.districts[i]) {
+		district := r.store.districtOn(r.store.districts[i], models.ValidityDate(ctx))
+		return &district, nil
+	}
+	return nil, nil
+}
+
+func (r *DistrictRepository) GetByID(ctx context.Context, id string) (*models.District, error) {
+	if district := r.store.district(id); district != nil {
+		district := r.store.districtOn(*district, models.ValidityDate(ctx))
+		return &district, nil
+	}
+	return nil, nil
+}
+
+func (r *DistrictRepository) GetByRegionSlug(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.District], error) {
+	return r.List(ctx, models.DistrictFilter{RegionSlug: regionSlug}, opts)
+}
+
+func (r *DistrictRepository) List(ctx context.Context, f models.DistrictFilter, opts models.ListOptions) (*models.List[models.District], error) {
+	districts := filter(r.validOn(ctx), func(d models.District) bool {
+		if f.RegionSlug != "" && r.store.region(d.RegionID).Slug != f.RegionSlug {
+			return false
+		}
+		return f.Type == "" || d.Type == f.Type
+	})
+
+	return queryList(listQuery[models.District]{
+		items: districts,
+		sortable: map[string]func(models.District) string{
+			"name": func(d models.District) string { return d.Name },
+			"slug": func(d models.District) string { return d.Slug },
+			"type": func(d models.District) string { return d.Type },
+		},
+		defaultSort: "name",
+		id:          func(d models.District) string { return d.ID },
+	}, opts)
+}
+
+func (r *DistrictRepository) GetByRegionIDs(ctx context.Context, regionIDs []string) (map[string][]models.District, error) {
+	wanted := make(map[string]bool, len(regionIDs))
+	for _, id := range regionIDs {
+		wanted[id] = true
+	}
+
+	sorted := r.validOn(ctx)
+	slices.SortFunc(sorted, func(a, b models.District) int { return cmp.Compare(a.Name, b.Name) })
+
+	districts := make(map[string][]models.District)
+	for _, d := range sorted {
+		if wanted[d.RegionID] {
+			districts[d.RegionID] = append(districts[d.RegionID], d)
+		}
+	}
+	return districts, nil
+}
+
+// validOn returns the districts valid on the as_of date of ctx, or today,
+// each in the region it belonged to on that date.
+func (r *DistrictRepository) validOn(ctx context.Context) []models.District {
+	date := models.ValidityDate(ctx)
+	districts := validOn(ctx, r.store.districts)
+	for i, district := range districts {
+		districts[i] = r.store.districtOn(district, date)
+	}
+	return districts
+}
+
+// GetBoundary returns nil: the embedded dataset has no boundary polygons.
+func (r *DistrictRepository) GetBoundary(ctx context.Context, slug string) (*models.Boundary, error) {
+	return nil, nil
+}
+
+// GetContaining returns nil: the embedded dataset has no boundary polygons.
+func (r *DistrictRepository) GetContaining(ctx context.Context, lat, lng float64) (*models.District, error) {
+	return nil, nil
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+// lineageLink is a link of lineage.json, as seeded into the lineage table.
+type lineageLink struct {
+	kind        string
+	typ         string
+	date        models.Date
+	predecessor string
+	successor   string
+}
+
+type LineageRepository struct {
+	store *Store
+}
+
+func NewLineageRepository(store *Store) *LineageRepository {
+	return &LineageRepository{store: store}
+}
+
+func (r *LineageRepository) GetPredecessors(ctx context.Context, kind, slug string) ([]models.LineageLink, error) {
+	return r.links(kind, slug, func(link lineageLink) (string, string) { return link.successor, link.predecessor })
+}
+
+func (r *LineageRepository) GetSuccessors(ctx context.Context, kind, slug string) ([]models.LineageLink, error) {
+	return r.links(kind, slug, func(link lineageLink) (string, string) { return link.predecessor, link.successor })
+}
+
+// links returns the records linked to slug. ends returns the slug a link is
+// matched on and the slug of the linked record.
+func (r *LineageRepository) links(kind, slug string, ends func(lineageLink) (from, to string)) ([]models.LineageLink, error) {
+	name, ok := r.nameFunc(kind)
+	if !ok {
+		return nil, fmt.Errorf("unknown lineage kind %q", kind)
+	}
+
+	links := []models.LineageLink{}
+	for _, link := range r.store.lineage {
+		from, to := ends(link)
+		if link.kind != kind || from != slug {
+			continue
+		}
+		if linkedName, exists := name(to); exists {
+			links = append(links, models.LineageLink{Type: link.typ, Date: link.date, Slug: to, Name: linkedName})
+		}
+	}
+	slices.SortStableFunc(links, func(a, b models.LineageLink) int {
+		return cmp.Or(a.Date.Compare(b.Date.Time), cmp.Compare(a.Name, b.Name))
+	})
+	return links, nil
+}
+
+// nameFunc returns a function looking up the names of records of kind by slug.
+func (r *LineageRepository) nameFunc(kind string) (func(slug string) (string, bool), bool) {
+	switch kind {
+	case "district":
+		return func(slug string) (string, bool) {
+			i, ok := r.store.districtBySlug[slug]
+			if !ok {
+				return "", false
+			}
+			return r.store.districts[i].Name, true
+		}, true
+	case "constituency":
+		return func(slug string) (string, bool) {
+			i, ok := r.store.constituencyBySlug[slug]
+			if !ok {
+				return "", false
+			}
+			return r.store.constituencies[i].Name, true
+		}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/repositories"
+)
+
+// listQuery mirrors the PostgreSQL list queries: items are ordered by the
+// sort field and id, and cursors use the same format.
+type listQuery[T any] struct {
+	items       []T
+	sortable    map[string]func(T) string
+	defaultSort string
+	id          func(T) string
+}
+
+func queryList[T any](q listQuery[T], opts models.ListOptions) (*models.List[T], error) {
+	limit := repositories.ListLimit(opts)
+	field, descending := repositories.ParseSort(opts.Sort, q.defaultSort)
+	key, ok := q.sortable[field]
+	if !ok {
+		return nil, errors.ErrInvalidSort
+	}
+
+	compare := func(value, id string, cursor repositories.ListCursor) int {
+		c := cmp.Or(cmp.Compare(value, cursor.Value), cmp.Compare(id, cursor.ID))
+		if descending {
+			return -c
+		}
+		return c
+	}
+
+	items := slices.Clone(q.items)
+	slices.SortFunc(items, func(a, b T) int {
+		return compare(key(a), q.id(a), repositories.ListCursor{Value: key(b), ID: q.id(b)})
+	})
+
+	start := 0
+	if opts.Cursor != "" {
+		cursor, err := repositories.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, errors.ErrInvalidCursor
+		}
+		start, _ = slices.BinarySearchFunc(items, cursor, func(item T, cursor repositories.ListCursor) int {
+			if compare(key(item), q.id(item), cursor) <= 0 {
+				return -1
+			}
+			return 1
+		})
+	}
+
+	list := &models.List[T]{Data: []T{}, Total: len(items)}
+	end := min(start+limit, len(items))
+	list.Data = append(list.Data, items[start:end]...)
+	if end < len(items) {
+		last := items[end-1]
+		next := repositories.EncodeCursor(repositories.ListCursor{Value: key(last), ID: q.id(last)})
+		list.NextCursor = &next
+	}
+	return list, nil
+}
+
+// filter returns the items matching keep.
+func filter[T any](items []T, keep func(T) bool) []T {
+	var kept []T
+	for _, item := range items {
+		if keep(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/repositories"
+)
+
+type item struct {
+	id, name string
+}
+
+// items returns n items whose names repeat every three, so that pages break
+// inside runs of equal sort values.
+func items(n int) []item {
+	var items []item
+	for i := range n {
+		items = append(items, item{
+			id:   fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+			name: []string{"Accra", "Bolgatanga", "Cape Coast"}[i%3],
+		})
+	}
+	return items
+}
+
+func newListQuery(items []item) listQuery[item] {
+	return listQuery[item]{
+		items:       items,
+		sortable:    map[string]func(item) string{"name": func(i item) string { return i.name }},
+		defaultSort: "name",
+		id:          func(i item) string { return i.id },
+	}
+}
+
+func TestQueryListPages(t *testing.T) {
+	for _, sort := range []string{"", "name", "-name"} {
+		for _, limit := range []int{1, 2, 3, 4, 10, 11} {
+			q := newListQuery(items(10))
+			opts := models.ListOptions{Limit: limit, Sort: sort}
+			var got []item
+			for page := 0; ; page++ {
+				if page > 10 {
+					t.Fatalf("sort %q, limit %d: did not reach the last page", sort, limit)
+				}
+				list, err := queryList(q, opts)
+				if err != nil {
+					t.Fatalf("sort %q, limit %d: %v", sort, limit, err)
+				}
+				if list.Total != 10 || len(list.Data) > limit {
+					t.Fatalf("sort %q, limit %d: page of %d of %d", sort, limit, len(list.Data), list.Total)
+				}
+				got = append(got, list.Data...)
+				if list.NextCursor == nil {
+					break
+				}
+				opts.Cursor = *list.NextCursor
+			}
+
+			if len(got) != 10 {
+				t.Fatalf("sort %q, limit %d: got %d items, want 10", sort, limit, len(got))
+			}
+			seen := make(map[string]bool)
+			for i, it := range got {
+				if seen[it.id] {
+					t.Errorf("sort %q, limit %d: %s returned twice", sort, limit, it.id)
+				}
+				seen[it.id] = true
+				if i == 0 {
+					continue
+				}
+				prev := got[i-1]
+				ordered := prev.name < it.name || prev.name == it.name && prev.id < it.id
+				if sort == "-name" {
+					ordered = prev.name > it.name || prev.name == it.name && prev.id > it.id
+				}
+				if !ordered {
+					t.Errorf("sort %q, limit %d: %+v before %+v", sort, limit, prev, it)
+				}
+			}
+		}
+	}
+}
+
+func TestQueryListEmpty(t *testing.T) {
+	list, err := queryList(newListQuery(nil), models.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Data == nil || len(list.Data) != 0 || list.Total != 0 || list.NextCursor != nil {
+		t.Errorf("queryList(nil) = %+v, want an empty page", list)
+	}
+}
+
+func TestQueryListCursorPastEnd(t *testing.T) {
+	cursor := repositories.EncodeCursor(repositories.ListCursor{Value: "Zebilla", ID: "00000000-0000-0000-0000-000000000000"})
+	list, err := queryList(newListQuery(items(10)), models.ListOptions{Cursor: cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Data) != 0 || list.NextCursor != nil {
+		t.Errorf("page after the last item = %+v, want empty", list)
+	}
+}
+
+func TestQueryListCursorOfRemovedItem(t *testing.T) {
+	// A cursor stays valid when its row is gone: the page starts at the
+	// next item in order.
+	all := items(10)
+	cursor := repositories.EncodeCursor(repositories.ListCursor{Value: all[4].name, ID: all[4].id})
+	remaining := append(append([]item{}, all[:4]...), all[5:]...)
+	list, err := queryList(newListQuery(remaining), models.ListOptions{Limit: 1, Cursor: cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Data) != 1 || list.Data[0] != all[7] {
+		t.Errorf("page after removed %+v = %+v, want %+v", all[4], list.Data, all[7])
+	}
+}
+
+func TestQueryListErrors(t *testing.T) {
+	q := newListQuery(items(3))
+	if _, err := queryList(q, models.ListOptions{Sort: "population"}); !stderrors.Is(err, errors.ErrInvalidSort) {
+		t.Errorf("unknown sort field: err = %v, want %v", err, errors.ErrInvalidSort)
+	}
+	if _, err := queryList(q, models.ListOptions{Cursor: "bogus"}); !stderrors.Is(err, errors.ErrInvalidCursor) {
+		t.Errorf("malformed cursor: err = %v, want %v", err, errors.ErrInvalidCursor)
+	}
+}
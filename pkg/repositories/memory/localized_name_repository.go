@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+type LocalizedNameRepository struct {
+	store *Store
+}
+
+func NewLocalizedNameRepository(store *Store) *LocalizedNameRepository {
+	return &LocalizedNameRepository{store: store}
+}
+
+func (r *LocalizedNameRepository) GetByLanguage(ctx context.Context, lang string) ([]models.LocalizedName, error) {
+	names := []models.LocalizedName{}
+	names = append(names, r.store.localizedNames[lang]...)
+	return names, nil
+}
+
+func (r *LocalizedNameRepository) GetLanguages(ctx context.Context) ([]string, error) {
+	languages := []string{}
+	for lang := range r.store.localizedNames {
+		languages = append(languages, lang)
+	}
+	slices.Sort(languages)
+	return languages, nil
+}
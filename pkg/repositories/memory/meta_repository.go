@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+type MetaRepository struct {
+	store *Store
+}
+
+func NewMetaRepository(store *Store) *MetaRepository {
+	return &MetaRepository{store: store}
+}
+
+func (r *MetaRepository) GetDatasetVersion(ctx context.Context) (*models.DatasetVersion, error) {
+	version := r.store.version
+	return &version, nil
+}
+
+func (r *MetaRepository) GetCounts(ctx context.Context) (*models.Counts, error) {
+	if err := r.store.loadPollingStations(); err != nil {
+		return nil, err
+	}
+	return &models.Counts{
+		Countries:       len(r.store.countries),
+		Regions:         len(validOn(ctx, r.store.regions)),
+		Districts:       len(validOn(ctx, r.store.districts)),
+		Constituencies:  len(validOn(ctx, r.store.constituencies)),
+		Cities:          len(r.store.cities),
+		PollingStations: len(r.store.pollingStations),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+type PollingStationRepository struct {
+	store *Store
+}
+
+func NewPollingStationRepository(store *Store) *PollingStationRepository {
+	return &PollingStationRepository{store: store}
+}
+
+func (r *PollingStationRepository) GetByCode(ctx context.Context, code string) (*models.PollingStation, error) {
+	if err := r.store.loadPollingStations(); err != nil {
+		return nil, err
+	}
+	if i, ok := r.store.pollingByCode[code]; ok {
+		station := r.store.pollingStations[i]
+		return &station, nil
+	}
+	return nil, nil
+}
+
+func (r *PollingStationRepository) GetByConstituencySlug(ctx context.Context, constituencySlug string, opts models.ListOptions) (*models.List[models.PollingStation], error) {
+	if err := r.store.loadPollingStations(); err != nil {
+		return nil, err
+	}
+
+	var stations []models.PollingStation
+	for _, i := range r.store.pollingByConstituency[constituencySlug] {
+		stations = append(stations, r.store.pollingStations[i])
+	}
+
+	return queryList(listQuery[models.PollingStation]{
+		items: stations,
+		sortable: map[string]func(models.PollingStation) string{
+			"code": func(p models.PollingStation) string { return p.Code },
+			"name": func(p models.PollingStation) string { return p.Name },
+		},
+		defaultSort: "code",
+		id:          func(p models.PollingStation) string { return p.ID },
+	}, opts)
+}
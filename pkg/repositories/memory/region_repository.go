@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+type RegionRepository struct {
+	store *Store
+}
+
+func NewRegionRepository(store *Store) *RegionRepository {
+	return &RegionRepository{store: store}
+}
+
+var regionSortable = map[string]func(models.Region) string{
+	"name": func(r models.Region) string { return r.Name },
+	"slug": func(r models.Region) string { return r.Slug },
+}
+
+func (r *RegionRepository) GetAll(ctx context.Context, opts models.ListOptions) (*models.List[models.Region], error) {
+	return queryList(listQuery[models.Region]{
+		items:       validOn(ctx, r.store.regions),
+		sortable:    regionSortable,
+		defaultSort: "name",
+		id:          func(r models.Region) string { return r.ID },
+	}, opts)
+}
+
+func (r *RegionRepository) GetBySlug(ctx context.Context, slug string) (*models.Region, error) {
+	if i, ok := r.store.regionBySlug[slug]; ok && asOf(ctx, r.store.regions[i]) {
+		region := r.store.regions[i]
+		return &region, nil
+	}
+	return nil, nil
+}
+
+func (r *RegionRepository) GetByID(ctx context.Context, id string) (*models.Region, error) {
+	if region := r.store.region(id); region != nil {
+		region := *region
+		return &region, nil
+	}
+	return nil, nil
+}
+
+func (r *RegionRepository) GetByECLetter(ctx context.Context, letter string) (*models.Region, error) {
+	if i, ok := r.store.regionByECLetter[letter]; ok {
+		region := r.store.regions[i]
+		return &region, nil
+	}
+	return nil, nil
+}
+
+// GetBoundary returns nil: the embedded dataset has no boundary polygons.
+func (r *RegionRepository) GetBoundary(ctx context.Context, slug string) (*models.Boundary, error) {
+	return nil, nil
+}
+
+// GetContaining returns nil: the embedded dataset has no boundary polygons.
+func (r *RegionRepository) GetContaining(ctx context.Context, lat, lng float64) (*models.Region, error) {
+	return nil, nil
+}
@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/ghana-location-api/pkg/models"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// similarityThreshold matches pg_trgm's default similarity_threshold.
+const similarityThreshold = 0.3
+
+type searchEntry struct {
+	result   models.SearchResult
+	validity models.Validity
+
+	// regionOf is the district whose region on the search date is the last
+	// of the parents, for districts and cities, as districts moved between
+	// regions.
+	regionOf string
+
+	name     string
+	trigrams map[string]bool
+}
+
+type SearchRepository struct {
+	store   *Store
+	entries []searchEntry
+}
+
+func NewSearchRepository(store *Store) *SearchRepository {
+	r := &SearchRepository{store: store}
+	add := func(typ, id, name, slug string, parents []models.SearchParent, validity models.Validity) {
+		normalized := normalizeSearch(name)
+		r.entries = append(r.entries, searchEntry{
+			result:   models.SearchResult{Type: typ, ID: id, Name: name, Slug: slug, Parents: parents},
+			validity: validity,
+			name:     normalized,
+			trigrams: trigrams(normalized),
+		})
+	}
+	regionParent := func(regionID string) []models.SearchParent {
+		region := store.region(regionID)
+		return []models.SearchParent{{Type: "region", Name: region.Name, Slug: region.Slug}}
+	}
+	districtParents := func(districtID string) []models.SearchParent {
+		district := store.district(districtID)
+		return append([]models.SearchParent{{Type: "district", Name: district.Name, Slug: district.Slug}}, regionParent(district.RegionID)...)
+	}
+
+	for _, region := range store.regions {
+		add("region", region.ID, region.Name, region.Slug, []models.SearchParent{}, region.Validity)
+	}
+	for _, district := range store.districts {
+		add("district", district.ID, district.Name, district.Slug, regionParent(district.RegionID), district.Validity)
+		r.entries[len(r.entries)-1].regionOf = district.ID
+	}
+	for _, constituency := range store.constituencies {
+		parents := []models.SearchParent{}
+		if constituency.DistrictID != nil {
+			district := store.district(*constituency.DistrictID)
+			parents = append(parents, models.SearchParent{Type: "district", Name: district.Name, Slug: district.Slug})
+		}
+		if constituency.RegionID != nil {
+			parents = append(parents, regionParent(*constituency.RegionID)...)
+		}
+		add("constituency", constituency.ID, constituency.Name, constituency.Slug, parents, constituency.Validity)
+	}
+	for _, city := range store.cities {
+		add("city", city.ID, city.Name, city.Slug, districtParents(city.DistrictID), models.Validity{})
+		r.entries[len(r.entries)-1].regionOf = city.DistrictID
+	}
+
+	return r
+}
+
+// Search ranks names the same way as the PostgreSQL search: a match at the
+// start of the name, then at the start of a later word, plus trigram similarity.
+func (r *SearchRepository) Search(ctx context.Context, query string, types []string, limit int) ([]models.SearchResult, error) {
+	term := normalizeSearch(query)
+	termTrigrams := trigrams(term)
+
+	date := models.ValidityDate(ctx)
+	results := []models.SearchResult{}
+	for _, entry := range r.entries {
+		if !slices.Contains(types, entry.result.Type) || !entry.validity.ValidOn(date) {
+			continue
+		}
+		similarity := trigramSimilarity(entry.trigrams, termTrigrams)
+		if !strings.Contains(entry.name, term) && similarity < similarityThreshold {
+			continue
+		}
+
+		result := entry.result
+		if entry.regionOf != "" {
+			result.Parents = r.withRegionOn(result.Parents, entry.regionOf, date)
+		}
+		result.Score = similarity
+		if strings.HasPrefix(entry.name, term) {
+			result.Score += 2
+		} else if strings.Contains(entry.name, " "+term) {
+			result.Score += 1
+		}
+		results = append(results, result)
+	}
+
+	slices.SortFunc(results, func(a, b models.SearchResult) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Name, b.Name))
+	})
+	return results[:min(limit, len(results))], nil
+}
+
+// withRegionOn returns parents with the last one, a region, replaced by the
+// region districtID was in on date.
+func (r *SearchRepository) withRegionOn(parents []models.SearchParent, districtID string, date models.Date) []models.SearchParent {
+	region := r.store.region(r.store.districtOn(*r.store.district(districtID), date).RegionID)
+	parents = slices.Clone(parents)
+	parents[len(parents)-1] = models.SearchParent{Type: "region", Name: region.Name, Slug: region.Slug}
+	return parents
+}
+
+// normalizeSearch lower-cases s and strips accents, like search_normalize in SQL.
+func normalizeSearch(s string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+	return strings.ToLower(folded)
+}
+
+// trigrams returns the pg_trgm trigram set of s: each alphanumeric word is
+// padded with two leading spaces and one trailing space.
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+	words := strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+func trigramSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range a {
+		if b[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
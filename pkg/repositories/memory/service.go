@@ -0,0 +1,20 @@
+package memory
+
+import "github.com/ghana-location-api/pkg/services"
+
+// NewLocationService returns a LocationService backed by the repositories of store.
+func NewLocationService(store *Store) *services.LocationService {
+	return services.NewLocationService(
+		NewCountryRepository(store),
+		NewRegionRepository(store),
+		NewDistrictRepository(store),
+		NewConstituencyRepository(store),
+		NewCityRepository(store),
+		NewPollingStationRepository(store),
+		NewSearchRepository(store),
+		NewLineageRepository(store),
+		NewAlternateNameRepository(store),
+		NewLocalizedNameRepository(store),
+		NewMetaRepository(store),
+	)
+}
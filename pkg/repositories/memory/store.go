@@ -0,0 +1,341 @@
+// Package memory implements the LocationService repositories over the
+// dataset files held in memory, for running without a database.
+package memory
+
+import (
+	"cmp"
+	"crypto/sha1"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sync"
+
+	"github.com/ghana-location-api/pkg/dataset"
+	"github.com/ghana-location-api/pkg/models"
+)
+
+// Store holds the dataset loaded the same way cmd/seed writes it to the
+// database: later rows replace earlier rows with the same slug, and cities
+// whose district is unknown or that have no name are dropped.
+type Store struct {
+	fsys    fs.FS
+	ds      *dataset.Dataset
+	version models.DatasetVersion
+
+	countries      []models.Country
+	regions        []models.Region
+	districts      []models.District
+	constituencies []models.Constituency
+	cities         []models.City
+	lineage        []lineageLink
+
+	// districtRegions holds the regions each district belonged to, keyed by
+	// district ID, for districts that moved between regions.
+	districtRegions map[string][]districtRegion
+
+	countryByCode      map[string]int
+	regionBySlug       map[string]int
+	regionByID         map[string]int
+	regionByECLetter   map[string]int
+	districtBySlug     map[string]int
+	districtByID       map[string]int
+	constituencyBySlug map[string]int
+	constituencyByID   map[string]int
+	cityBySlug         map[string]int
+
+	// alternateNames is keyed by kind and name key, as in alternateNameKey.
+	alternateNames map[string]models.AlternateName
+
+	// localizedNames holds the localized names of each language, in the
+	// order of the data file.
+	localizedNames map[string][]models.LocalizedName
+
+	// constituenciesByECNumber is keyed by region ID and EC constituency
+	// number, as in ecNumberKey. A constituency that was split shares its
+	// number with the constituencies split from it.
+	constituenciesByECNumber map[string][]int
+
+	// citiesByLat holds the indexes of the cities with coordinates, ordered
+	// by latitude, so radius searches only scan a band of latitudes.
+	citiesByLat []int
+
+	// Polling stations are parsed on first use, as the source file is large.
+	pollingOnce           sync.Once
+	pollingErr            error
+	pollingStations       []models.PollingStation
+	pollingByCode         map[string]int
+	pollingByConstituency map[string][]int
+}
+
+// NewStore loads the dataset files from the root of fsys, e.g. the embedded data.FS.
+func NewStore(fsys fs.FS) (*Store, error) {
+	ds, err := dataset.Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	// The data files carry no seed time, so SeededAt is left unset rather
+	// than dated from when the server started.
+	version, err := dataset.Version(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		fsys:               fsys,
+		ds:                 ds,
+		version:            models.DatasetVersion{Version: version},
+		countryByCode:      make(map[string]int),
+		regionBySlug:       make(map[string]int),
+		regionByID:         make(map[string]int),
+		regionByECLetter:   make(map[string]int),
+		districtBySlug:     make(map[string]int),
+		districtByID:       make(map[string]int),
+		constituencyBySlug: make(map[string]int),
+		constituencyByID:   make(map[string]int),
+		cityBySlug:         make(map[string]int),
+
+		constituenciesByECNumber: make(map[string][]int),
+		alternateNames:           make(map[string]models.AlternateName),
+		localizedNames:           make(map[string][]models.LocalizedName),
+		districtRegions:          make(map[string][]districtRegion),
+	}
+
+	for _, c := range ds.Countries {
+		country := models.Country{ID: stableID("country", c.Code), Code: c.Code, Name: c.Name}
+		upsert(&s.countries, s.countryByCode, c.Code, country)
+	}
+
+	countryID := ""
+	if i, ok := s.countryByCode["GH"]; ok {
+		countryID = s.countries[i].ID
+	}
+	for _, r := range ds.Regions {
+		validity, err := parseValidity(r.Validity)
+		if err != nil {
+			return nil, fmt.Errorf("region %s: %w", r.Slug, err)
+		}
+		region := models.Region{ID: stableID("region", r.Slug), CountryID: countryID, Name: r.Name, Slug: r.Slug, Capital: r.Capital, Validity: validity}
+		upsert(&s.regions, s.regionBySlug, r.Slug, region)
+	}
+	for i, region := range s.regions {
+		s.regionByID[region.ID] = i
+	}
+	for _, r := range ds.Regions {
+		if r.ECLetter != "" {
+			s.regionByECLetter[r.ECLetter] = s.regionBySlug[r.Slug]
+		}
+	}
+
+	for _, d := range ds.Districts {
+		i, ok := s.regionBySlug[d.RegionSlug]
+		if !ok {
+			return nil, fmt.Errorf("region not found: %s", d.RegionSlug)
+		}
+		validity, err := parseValidity(d.Validity)
+		if err != nil {
+			return nil, fmt.Errorf("district %s: %w", d.Slug, err)
+		}
+		district := models.District{ID: stableID("district", d.Slug), RegionID: s.regions[i].ID, Name: d.Name, Slug: d.Slug, Type: d.Type, Capital: d.Capital, Validity: validity}
+		upsert(&s.districts, s.districtBySlug, d.Slug, district)
+	}
+	for i, district := range s.districts {
+		s.districtByID[district.ID] = i
+	}
+	for _, p := range ds.DistrictRegions() {
+		i, ok := s.regionBySlug[p.RegionSlug]
+		if !ok {
+			return nil, fmt.Errorf("region not found: %s", p.RegionSlug)
+		}
+		validity, err := parseValidity(p.Validity)
+		if err != nil {
+			return nil, fmt.Errorf("district %s in %s: %w", p.DistrictSlug, p.RegionSlug, err)
+		}
+		id := stableID("district", p.DistrictSlug)
+		s.districtRegions[id] = append(s.districtRegions[id], districtRegion{regionID: s.regions[i].ID, validity: validity})
+	}
+
+	constituencyRegions := ds.ConstituencyRegions()
+	for _, c := range ds.Constituencies {
+		validity, err := parseValidity(c.Validity)
+		if err != nil {
+			return nil, fmt.Errorf("constituency %s: %w", c.Slug, err)
+		}
+		constituency := models.Constituency{ID: stableID("constituency", c.Slug), Name: c.Name, Slug: c.Slug, Validity: validity}
+		if i, ok := s.regionBySlug[constituencyRegions[c.Slug]]; ok {
+			constituency.RegionID = &s.regions[i].ID
+		}
+		if c.DistrictSlug != nil {
+			if i, ok := s.districtBySlug[*c.DistrictSlug]; ok {
+				constituency.DistrictID = &s.districts[i].ID
+			}
+		}
+		upsert(&s.constituencies, s.constituencyBySlug, c.Slug, constituency)
+	}
+	for i, constituency := range s.constituencies {
+		s.constituencyByID[constituency.ID] = i
+	}
+	for _, c := range ds.Constituencies {
+		i := s.constituencyBySlug[c.Slug]
+		if c.ECNumber != nil && s.constituencies[i].RegionID != nil {
+			key := ecNumberKey(*s.constituencies[i].RegionID, *c.ECNumber)
+			s.constituenciesByECNumber[key] = append(s.constituenciesByECNumber[key], i)
+		}
+	}
+
+	citySlugs := ds.CitySlugs(nil)
+	cityByKey := make(map[string]int)
+	for _, c := range ds.Cities {
+		i, ok := s.districtBySlug[c.DistrictSlug]
+		if !ok || c.Name == "" {
+			continue
+		}
+		key := dataset.CityKey(c.DistrictSlug, c.Name)
+		city := models.City{ID: stableID("city", key), DistrictID: s.districts[i].ID, Name: c.Name, Slug: citySlugs[key], Lat: c.Lat, Lng: c.Lng}
+		upsert(&s.cities, cityByKey, key, city)
+	}
+	for i, city := range s.cities {
+		s.cityBySlug[city.Slug] = i
+		if city.Lat != nil && city.Lng != nil {
+			s.citiesByLat = append(s.citiesByLat, i)
+		}
+	}
+	slices.SortFunc(s.citiesByLat, func(a, b int) int { return cmp.Compare(*s.cities[a].Lat, *s.cities[b].Lat) })
+
+	for _, l := range ds.LineageLinks() {
+		date, err := models.ParseDate(l.Date)
+		if err != nil {
+			return nil, fmt.Errorf("lineage of %s %s: %w", l.Kind, l.Predecessor, err)
+		}
+		s.lineage = append(s.lineage, lineageLink{kind: l.Kind, typ: l.Type, date: date, predecessor: l.Predecessor, successor: l.Successor})
+	}
+
+	for _, n := range ds.AlternateNames {
+		key := alternateNameKey(n.Kind, dataset.Slugify(n.Name))
+		s.alternateNames[key] = models.AlternateName{Kind: n.Kind, Slug: n.Slug, Name: n.Name, Type: n.Type}
+	}
+
+	for _, n := range ds.LocalizedNames {
+		s.localizedNames[n.Lang] = append(s.localizedNames[n.Lang], models.LocalizedName{Kind: n.Kind, Slug: n.Slug, Lang: n.Lang, Name: n.Name})
+	}
+
+	return s, nil
+}
+
+func (s *Store) region(id string) *models.Region {
+	if i, ok := s.regionByID[id]; ok {
+		return &s.regions[i]
+	}
+	return nil
+}
+
+func (s *Store) district(id string) *models.District {
+	if i, ok := s.districtByID[id]; ok {
+		return &s.districts[i]
+	}
+	return nil
+}
+
+// districtRegion is a period in which a district belonged to a region.
+type districtRegion struct {
+	regionID string
+	validity models.Validity
+}
+
+// districtOn returns district with the region it belonged to on date, or its
+// current region when it has no period covering the date.
+func (s *Store) districtOn(district models.District, date models.Date) models.District {
+	for _, p := range s.districtRegions[district.ID] {
+		if p.validity.ValidOn(date) {
+			district.RegionID = p.regionID
+			break
+		}
+	}
+	return district
+}
+
+// districtRegionSlug returns the slug of the region a district belonged to
+// on date.
+func (s *Store) districtRegionSlug(districtID string, date models.Date) string {
+	if district := s.district(districtID); district != nil {
+		if region := s.region(s.districtOn(*district, date).RegionID); region != nil {
+			return region.Slug
+		}
+	}
+	return ""
+}
+
+func (s *Store) loadPollingStations() error {
+	s.pollingOnce.Do(func() {
+		stations, err := dataset.LoadPollingStations(s.fsys, s.ds)
+		if err != nil {
+			s.pollingErr = err
+			return
+		}
+
+		s.pollingByCode = make(map[string]int, len(stations))
+		s.pollingByConstituency = make(map[string][]int)
+		for _, p := range stations {
+			i, ok := s.regionBySlug[p.RegionSlug]
+			if !ok {
+				continue
+			}
+			station := models.PollingStation{ID: stableID("polling-station", p.Code), RegionID: s.regions[i].ID, Code: p.Code, Name: p.Name}
+			if p.DistrictSlug != nil {
+				if i, ok := s.districtBySlug[*p.DistrictSlug]; ok {
+					station.DistrictID = &s.districts[i].ID
+				}
+			}
+			if p.ConstituencySlug != nil {
+				if i, ok := s.constituencyBySlug[*p.ConstituencySlug]; ok {
+					station.ConstituencyID = &s.constituencies[i].ID
+					s.pollingByConstituency[*p.ConstituencySlug] = append(s.pollingByConstituency[*p.ConstituencySlug], len(s.pollingStations))
+				}
+			}
+			s.pollingByCode[p.Code] = len(s.pollingStations)
+			s.pollingStations = append(s.pollingStations, station)
+		}
+	})
+	return s.pollingErr
+}
+
+// upsert appends item under key, or replaces the item already stored under key.
+func upsert[T any](items *[]T, index map[string]int, key string, item T) {
+	if i, ok := index[key]; ok {
+		(*items)[i] = item
+		return
+	}
+	index[key] = len(*items)
+	*items = append(*items, item)
+}
+
+// stableID derives a UUID (version 5 layout) from a record's natural key so
+// ids, and cursors built from them, survive restarts.
+func stableID(kind, key string) string {
+	sum := sha1.Sum([]byte(kind + ":" + key))
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+func ecNumberKey(regionID string, number int) string {
+	return fmt.Sprintf("%s/%d", regionID, number)
+}
+
+func alternateNameKey(kind, key string) string {
+	return kind + "/" + key
+}
+
+func parseValidity(v dataset.Validity) (models.Validity, error) {
+	from, to, err := v.Dates()
+	if err != nil {
+		return models.Validity{}, err
+	}
+	var validity models.Validity
+	if from != nil {
+		validity.ValidFrom = &models.Date{Time: *from}
+	}
+	if to != nil {
+		validity.ValidTo = &models.Date{Time: *to}
+	}
+	return validity, nil
+}
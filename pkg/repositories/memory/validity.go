@@ -0,0 +1,26 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+// dated is implemented by the models that embed models.Validity.
+type dated interface {
+	ValidOn(date models.Date) bool
+}
+
+// validOn keeps the items valid on the as_of date of ctx, or today, as the
+// PostgreSQL lists do.
+func validOn[T dated](ctx context.Context, items []T) []T {
+	date := models.ValidityDate(ctx)
+	return filter(items, func(item T) bool { return item.ValidOn(date) })
+}
+
+// asOf reports whether item is valid on the as_of date of ctx. Without one,
+// every item is, so retired records still resolve by slug.
+func asOf(ctx context.Context, item dated) bool {
+	date, ok := models.AsOf(ctx)
+	return !ok || item.ValidOn(date)
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MetaRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMetaRepository(pool *pgxpool.Pool) *MetaRepository {
+	return &MetaRepository{pool: pool}
+}
+
+// GetDatasetVersion returns the version recorded by the latest seed.
+func (r *MetaRepository) GetDatasetVersion(ctx context.Context) (*models.DatasetVersion, error) {
+	var version models.DatasetVersion
+	err := r.pool.QueryRow(ctx, "SELECT id, version, seeded_at, tables FROM dataset_versions ORDER BY id DESC LIMIT 1").
+		Scan(&version.Seed, &version.Version, &version.SeededAt, &version.Tables)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &version, nil
+}
+
+// GetCounts counts the rows of each table. Regions, districts and
+// constituencies are counted when valid on the as_of date of ctx, or today.
+func (r *MetaRepository) GetCounts(ctx context.Context) (*models.Counts, error) {
+	valid := func(table string) string {
+		return fmt.Sprintf(validityCondition(table), 1)
+	}
+
+	var counts models.Counts
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			(SELECT count(*) FROM countries),
+			(SELECT count(*) FROM regions WHERE `+valid("regions")+`),
+			(SELECT count(*) FROM districts WHERE `+valid("districts")+`),
+			(SELECT count(*) FROM constituencies WHERE `+valid("constituencies")+`),
+			(SELECT count(*) FROM cities),
+			(SELECT count(*) FROM polling_stations)
+	`, models.ValidityDate(ctx).Time).Scan(&counts.Countries, &counts.Regions, &counts.Districts, &counts.Constituencies, &counts.Cities, &counts.PollingStations)
+	if err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PollingStationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPollingStationRepository(pool *pgxpool.Pool) *PollingStationRepository {
+	return &PollingStationRepository{pool: pool}
+}
+
+func (r *PollingStationRepository) GetByCode(ctx context.Context, code string) (*models.PollingStation, error) {
+	var station models.PollingStation
+	err := r.pool.QueryRow(ctx, "SELECT id, region_id, district_id, constituency_id, code, name FROM polling_stations WHERE code = $1", code).
+		Scan(&station.ID, &station.RegionID, &station.DistrictID, &station.ConstituencyID, &station.Code, &station.Name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &station, nil
+}
+
+func (r *PollingStationRepository) GetByConstituencySlug(ctx context.Context, constituencySlug string, opts models.ListOptions) (*models.List[models.PollingStation], error) {
+	return queryList(ctx, r.pool, listQuery[models.PollingStation]{
+		query: `
+			SELECT p.id, p.region_id, p.district_id, p.constituency_id, p.code, p.name
+			FROM polling_stations p
+			JOIN constituencies c ON p.constituency_id = c.id
+			WHERE c.slug = $1`,
+		args:        []any{constituencySlug},
+		sortable:    map[string]string{"code": "code", "name": "name"},
+		defaultSort: "code",
+		scan:        scanPollingStation,
+	}, opts)
+}
+
+func scanPollingStation(rows pgx.Rows, extra ...any) (models.PollingStation, error) {
+	var station models.PollingStation
+	err := rows.Scan(append([]any{&station.ID, &station.RegionID, &station.DistrictID, &station.ConstituencyID, &station.Code, &station.Name}, extra...)...)
+	return station, err
+}
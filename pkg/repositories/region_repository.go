@@ -1,49 +1,119 @@
-package repositories
-
-import (
-	"context"
-
-	"github.com/ghana-location-api/pkg/models"
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
-)
-
-type RegionRepository struct {
-	pool *pgxpool.Pool
-}
-
-func NewRegionRepository(pool *pgxpool.Pool) *RegionRepository {
-	return &RegionRepository{pool: pool}
-}
-
-func (r *RegionRepository) GetAll(ctx context.Context) ([]models.Region, error) {
-	rows, err := r.pool.Query(ctx, "SELECT id, country_id, name, slug, capital FROM regions ORDER BY name")
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var regions []models.Region
-	for rows.Next() {
-		var region models.Region
-		if err := rows.Scan(&region.ID, &region.CountryID, &region.Name, &region.Slug, &region.Capital); err != nil {
-			return nil, err
-		}
-		regions = append(regions, region)
-	}
-
-	return regions, rows.Err()
-}
-
-func (r *RegionRepository) GetBySlug(ctx context.Context, slug string) (*models.Region, error) {
-	var region models.Region
-	err := r.pool.QueryRow(ctx, "SELECT id, country_id, name, slug, capital FROM regions WHERE slug = $1", slug).
-		Scan(&region.ID, &region.CountryID, &region.Name, &region.Slug, &region.Capital)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
-	}
-	return &region, nil
-}
+package repositories
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RegionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRegionRepository(pool *pgxpool.Pool) *RegionRepository {
+	return &RegionRepository{pool: pool}
+}
+
+// regionColumns are the columns scanned into a models.Region, in order.
+const regionColumns = "id, country_id, name, slug, capital, valid_from, valid_to"
+
+func (r *RegionRepository) GetAll(ctx context.Context, opts models.ListOptions) (*models.List[models.Region], error) {
+	var where conditions
+	where.validOn(ctx, "regions")
+
+	return queryList(ctx, r.pool, listQuery[models.Region]{
+		query:       "SELECT " + regionColumns + " FROM regions" + where.sql(),
+		args:        where.args,
+		sortable:    map[string]string{"name": "name", "slug": "slug"},
+		defaultSort: "name",
+		scan:        scanRegion,
+	}, opts)
+}
+
+func scanRegion(rows pgx.Rows, extra ...any) (models.Region, error) {
+	var region models.Region
+	err := rows.Scan(append([]any{&region.ID, &region.CountryID, &region.Name, &region.Slug, &region.Capital, &region.ValidFrom, &region.ValidTo}, extra...)...)
+	return region, err
+}
+
+func (r *RegionRepository) GetBySlug(ctx context.Context, slug string) (*models.Region, error) {
+	var where conditions
+	where.add("slug = $%d", slug)
+	where.asOf(ctx, "regions")
+
+	var region models.Region
+	err := r.pool.QueryRow(ctx, "SELECT "+regionColumns+" FROM regions"+where.sql(), where.args...).
+		Scan(&region.ID, &region.CountryID, &region.Name, &region.Slug, &region.Capital, &region.ValidFrom, &region.ValidTo)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &region, nil
+}
+
+func (r *RegionRepository) GetByID(ctx context.Context, id string) (*models.Region, error) {
+	var region models.Region
+	err := r.pool.QueryRow(ctx, "SELECT "+regionColumns+" FROM regions WHERE id = $1", id).
+		Scan(&region.ID, &region.CountryID, &region.Name, &region.Slug, &region.Capital, &region.ValidFrom, &region.ValidTo)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &region, nil
+}
+
+// GetByECLetter returns the region whose polling station codes start with letter.
+func (r *RegionRepository) GetByECLetter(ctx context.Context, letter string) (*models.Region, error) {
+	var region models.Region
+	err := r.pool.QueryRow(ctx, "SELECT "+regionColumns+" FROM regions WHERE ec_letter = $1", letter).
+		Scan(&region.ID, &region.CountryID, &region.Name, &region.Slug, &region.Capital, &region.ValidFrom, &region.ValidTo)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &region, nil
+}
+
+func (r *RegionRepository) GetBoundary(ctx context.Context, slug string) (*models.Boundary, error) {
+	boundary := models.Boundary{Type: "Feature", Properties: models.BoundaryProperties{Type: "region"}}
+	var where conditions
+	where.add("slug = $%d", slug)
+	where.add("boundary IS NOT NULL")
+	where.asOf(ctx, "regions")
+
+	var geometry string
+	err := r.pool.QueryRow(ctx, "SELECT name, slug, ST_AsGeoJSON(boundary) FROM regions"+where.sql(), where.args...).
+		Scan(&boundary.Properties.Name, &boundary.Properties.Slug, &geometry)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	boundary.Geometry = []byte(geometry)
+	return &boundary, nil
+}
+
+func (r *RegionRepository) GetContaining(ctx context.Context, lat, lng float64) (*models.Region, error) {
+	where := conditions{args: []any{lat, lng}}
+	where.add("ST_Contains(boundary, ST_SetSRID(ST_MakePoint($2, $1), 4326))")
+	where.validOn(ctx, "regions")
+
+	var region models.Region
+	err := r.pool.QueryRow(ctx, "SELECT "+regionColumns+" FROM regions"+where.sql()+" LIMIT 1", where.args...).Scan(&region.ID, &region.CountryID, &region.Name, &region.Slug, &region.Capital, &region.ValidFrom, &region.ValidTo)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &region, nil
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SearchRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewSearchRepository(pool *pgxpool.Pool) *SearchRepository {
+	return &SearchRepository{pool: pool}
+}
+
+// Search matches query against region, district, constituency and city names.
+// Names are compared lower-cased and accent-folded; a match at the start of
+// the name ranks above a match at the start of a later word, which ranks above
+// a fuzzy trigram match. The query is matched literally, so % and _ are not
+// wildcards. Regions, districts and constituencies are limited to those valid
+// on the as_of date of ctx, or today.
+func (r *SearchRepository) Search(ctx context.Context, query string, types []string, limit int) ([]models.SearchResult, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH n AS (SELECT search_normalize($1) AS term),
+		q AS (SELECT term, replace(replace(replace(term, '\', '\\'), '%', '\%'), '_', '\_') AS pattern FROM n)
+		SELECT h.type, h.id, h.name, h.slug, h.district_name, h.district_slug, h.region_name, h.region_slug,
+			CASE
+				WHEN search_normalize(h.name) LIKE q.pattern || '%' ESCAPE '\' THEN 2
+				WHEN search_normalize(h.name) LIKE '% ' || q.pattern || '%' ESCAPE '\' THEN 1
+				ELSE 0
+			END + similarity(search_normalize(h.name), q.term) AS score
+		FROM (
+			SELECT 'region' AS type, r.id, r.name, r.slug,
+				NULL::varchar AS district_name, NULL::varchar AS district_slug, NULL::varchar AS region_name, NULL::varchar AS region_slug
+			FROM regions r
+			WHERE `+fmt.Sprintf(validityCondition("r"), 4)+`
+			UNION ALL
+			SELECT 'district', d.id, d.name, d.slug, NULL, NULL, r.name, r.slug
+			FROM districts d
+			JOIN regions r ON r.id = `+districtRegionSQL("d", 4)+`
+			WHERE `+fmt.Sprintf(validityCondition("d"), 4)+`
+			UNION ALL
+			SELECT 'constituency', c.id, c.name, c.slug, d.name, d.slug, r.name, r.slug
+			FROM constituencies c
+			LEFT JOIN districts d ON c.district_id = d.id
+			LEFT JOIN regions r ON c.region_id = r.id
+			WHERE `+fmt.Sprintf(validityCondition("c"), 4)+`
+			UNION ALL
+			SELECT 'city', ci.id, ci.name, ci.slug, d.name, d.slug, r.name, r.slug
+			FROM cities ci
+			JOIN districts d ON ci.district_id = d.id
+			JOIN regions r ON r.id = `+districtRegionSQL("d", 4)+`
+		) h, q
+		WHERE h.type = ANY($2)
+		  AND (search_normalize(h.name) LIKE '%' || q.pattern || '%' ESCAPE '\' OR search_normalize(h.name) % q.term)
+		ORDER BY score DESC, h.name
+		LIMIT $3
+	`, query, types, limit, models.ValidityDate(ctx).Time)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []models.SearchResult{}
+	for rows.Next() {
+		var result models.SearchResult
+		var slug, districtName, districtSlug, regionName, regionSlug *string
+		if err := rows.Scan(&result.Type, &result.ID, &result.Name, &slug, &districtName, &districtSlug, &regionName, &regionSlug, &result.Score); err != nil {
+			return nil, err
+		}
+		if slug != nil {
+			result.Slug = *slug
+		}
+		result.Parents = []models.SearchParent{}
+		if districtSlug != nil {
+			result.Parents = append(result.Parents, models.SearchParent{Type: "district", Name: *districtName, Slug: *districtSlug})
+		}
+		if regionSlug != nil {
+			result.Parents = append(result.Parents, models.SearchParent{Type: "region", Name: *regionName, Slug: *regionSlug})
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
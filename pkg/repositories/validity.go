@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+// validityCondition is the condition that a row of the table or alias is
+// valid on the date passed as the condition's argument.
+func validityCondition(alias string) string {
+	return fmt.Sprintf("(%[1]s.valid_from IS NULL OR %[1]s.valid_from <= $%%[1]d) AND (%[1]s.valid_to IS NULL OR %[1]s.valid_to > $%%[1]d)", alias)
+}
+
+// validOn restricts alias to rows valid on the as_of date of ctx, or today.
+// Lists and other set queries use it.
+func (c *conditions) validOn(ctx context.Context, alias string) {
+	c.add(validityCondition(alias), models.ValidityDate(ctx).Time)
+}
+
+// asOf restricts alias to rows valid on the as_of date of ctx, if one is
+// set. Lookups by slug use it, so retired rows resolve when no date is asked for.
+func (c *conditions) asOf(ctx context.Context, alias string) {
+	if date, ok := models.AsOf(ctx); ok {
+		c.add(validityCondition(alias), date.Time)
+	}
+}
+
+// districtRegionSQL is the region of district alias on the date passed as
+// argument number param: the region of its district_regions period covering
+// the date, or its current region when it has no history.
+func districtRegionSQL(alias string, param int) string {
+	return fmt.Sprintf("COALESCE((SELECT dr.region_id FROM district_regions dr WHERE dr.district_id = %s.id AND %s), %s.region_id)",
+		alias, fmt.Sprintf(validityCondition("dr"), param), alias)
+}
+
+// districtRegion returns the region of district alias on the as_of date of
+// ctx, or today, adding the date to the arguments.
+func (c *conditions) districtRegion(ctx context.Context, alias string) string {
+	c.args = append(c.args, models.ValidityDate(ctx).Time)
+	return districtRegionSQL(alias, len(c.args))
+}
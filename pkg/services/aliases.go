@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/ghana-location-api/pkg/dataset"
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+)
+
+// lookupSlug returns what get finds for slug. When it finds nothing, it
+// returns an *errors.AliasError if slug names a record of kind in another
+// way (see checkAlias), and errors.ErrNotFound otherwise.
+func lookupSlug[T any](ctx context.Context, s *LocationService, kind, slug string, get func(context.Context, string) (*T, error)) (*T, error) {
+	if err := s.validateSlug(slug); err != nil {
+		return nil, err
+	}
+	item, err := get(ctx, slug)
+	if err != nil || item != nil {
+		return item, err
+	}
+	if err := s.checkAlias(ctx, kind, slug); err != nil {
+		return nil, err
+	}
+	return nil, errors.ErrNotFound
+}
+
+// listBySlug returns what list finds for the children of the record of kind
+// with slug. An empty list for a slug that names a record in another way
+// returns an *errors.AliasError instead.
+func listBySlug[T any](ctx context.Context, s *LocationService, kind, slug string, opts models.ListOptions, list func(context.Context, string, models.ListOptions) (*models.List[T], error)) (*models.List[T], error) {
+	if err := s.validateSlug(slug); err != nil {
+		return nil, err
+	}
+	result, err := list(ctx, slug, opts)
+	if err != nil || len(result.Data) > 0 {
+		return result, err
+	}
+	if err := s.checkAlias(ctx, kind, slug); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkAlias returns an *errors.AliasError when slug is not the slug of a
+// record of kind but names one: with other case or punctuation, as in
+// "Ketu South Municipal", or by an alternate name, as in "Brong Ahafo".
+func (s *LocationService) checkAlias(ctx context.Context, kind, slug string) error {
+	key := dataset.Slugify(slug)
+	if key != slug {
+		exists, err := s.slugExists(ctx, kind, key)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.Alias(slug, key)
+		}
+	}
+
+	name, err := s.altNameRepo.GetByKey(ctx, kind, key)
+	if err != nil {
+		return err
+	}
+	if name != nil {
+		return errors.Alias(slug, name.Slug)
+	}
+	return nil
+}
+
+func (s *LocationService) slugExists(ctx context.Context, kind, slug string) (bool, error) {
+	switch kind {
+	case "region":
+		return found(s.regionRepo.GetBySlug(ctx, slug))
+	case "district":
+		return found(s.districtRepo.GetBySlug(ctx, slug))
+	case "constituency":
+		return found(s.constituencyRepo.GetBySlug(ctx, slug))
+	case "city":
+		return found(s.cityRepo.GetBySlug(ctx, slug))
+	}
+	return false, nil
+}
+
+func found[T any](item *T, err error) (bool, error) {
+	return item != nil, err
+}
+
+// canonicalSlug returns the slug of the record of kind that a filter slug
+// names, so that filters accept the same aliases lookups redirect from. An
+// empty slug, which filters nothing, is returned as is.
+func (s *LocationService) canonicalSlug(ctx context.Context, kind, slug string) (string, error) {
+	if slug == "" {
+		return "", nil
+	}
+	if err := s.validateSlug(slug); err != nil {
+		return "", err
+	}
+	err := s.checkAlias(ctx, kind, slug)
+	var alias *errors.AliasError
+	if stderrors.As(err, &alias) {
+		return alias.Canonical, nil
+	}
+	return slug, err
+}
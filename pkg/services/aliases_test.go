@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/ghana-location-api/data"
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/repositories/memory"
+	"github.com/ghana-location-api/pkg/services"
+)
+
+// newService returns a LocationService over the embedded dataset.
+func newService(t *testing.T) *services.LocationService {
+	t.Helper()
+	store, err := memory.NewStore(data.FS)
+	if err != nil {
+		t.Fatalf("failed to load dataset: %v", err)
+	}
+	return memory.NewLocationService(store)
+}
+
+func TestAliases(t *testing.T) {
+	s := newService(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		slug      string
+		canonical string // empty when slug is canonical
+		err       error
+	}{
+		{slug: "ashanti-region"},
+		{slug: "ashanti", canonical: "ashanti-region"},
+		{slug: "Ashanti-Region", canonical: "ashanti-region"},
+		{slug: "Ashanti Region", canonical: "ashanti-region"},
+		{slug: "brong-ahafo", canonical: "brong-ahafo-region"},
+		{slug: "atlantis", err: errors.ErrNotFound},
+		{slug: "", err: errors.ErrInvalidSlug},
+		{slug: "--", err: errors.ErrInvalidSlug},
+	}
+	for _, tt := range tests {
+		region, err := s.GetRegionBySlug(ctx, tt.slug)
+		var alias *errors.AliasError
+		switch {
+		case tt.err != nil:
+			if !stderrors.Is(err, tt.err) {
+				t.Errorf("GetRegionBySlug(%q) error = %v, want %v", tt.slug, err, tt.err)
+			}
+		case tt.canonical != "":
+			if !stderrors.As(err, &alias) || alias.Canonical != tt.canonical {
+				t.Errorf("GetRegionBySlug(%q) error = %v, want an alias of %s", tt.slug, err, tt.canonical)
+			}
+		default:
+			if err != nil || region == nil || region.Slug != tt.slug {
+				t.Errorf("GetRegionBySlug(%q) = %+v, %v", tt.slug, region, err)
+			}
+		}
+	}
+}
+
+func TestFilterAliases(t *testing.T) {
+	s := newService(t)
+	ctx := context.Background()
+
+	want, err := s.ListDistricts(ctx, models.DistrictFilter{RegionSlug: "ashanti-region"}, models.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.ListDistricts(ctx, models.DistrictFilter{RegionSlug: "Ashanti"}, models.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want.Total == 0 || got.Total != want.Total {
+		t.Errorf("districts in region Ashanti = %d, in ashanti-region = %d", got.Total, want.Total)
+	}
+}
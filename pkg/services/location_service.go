@@ -1,128 +1,741 @@
-package services
-
-import (
-	"context"
-	"strings"
-
-	"github.com/ghana-location-api/pkg/models"
-	"github.com/ghana-location-api/pkg/repositories"
-	"github.com/ghana-location-api/pkg/errors"
-)
-
-type LocationService struct {
-	countryRepo      *repositories.CountryRepository
-	regionRepo       *repositories.RegionRepository
-	districtRepo     *repositories.DistrictRepository
-	constituencyRepo *repositories.ConstituencyRepository
-	cityRepo         *repositories.CityRepository
-}
-
-func NewLocationService(
-	countryRepo *repositories.CountryRepository,
-	regionRepo *repositories.RegionRepository,
-	districtRepo *repositories.DistrictRepository,
-	constituencyRepo *repositories.ConstituencyRepository,
-	cityRepo *repositories.CityRepository,
-) *LocationService {
-	return &LocationService{
-		countryRepo:      countryRepo,
-		regionRepo:       regionRepo,
-		districtRepo:     districtRepo,
-		constituencyRepo: constituencyRepo,
-		cityRepo:         cityRepo,
-	}
-}
-
-func (s *LocationService) validateSlug(slug string) error {
-	if slug == "" || strings.Contains(slug, " ") || strings.ContainsAny(slug, "!@#$%^&*()") {
-		return errors.ErrInvalidSlug
-	}
-	return nil
-}
-
-// Country methods
-func (s *LocationService) GetAllCountries(ctx context.Context) ([]models.Country, error) {
-	return s.countryRepo.GetAll(ctx)
-}
-
-func (s *LocationService) GetCountryByCode(ctx context.Context, code string) (*models.Country, error) {
-	country, err := s.countryRepo.GetByCode(ctx, code)
-	if err != nil {
-		return nil, err
-	}
-	if country == nil {
-		return nil, errors.ErrNotFound
-	}
-	return country, nil
-}
-
-// Region methods
-func (s *LocationService) GetAllRegions(ctx context.Context) ([]models.Region, error) {
-	return s.regionRepo.GetAll(ctx)
-}
-
-func (s *LocationService) GetRegionBySlug(ctx context.Context, slug string) (*models.Region, error) {
-	if err := s.validateSlug(slug); err != nil {
-		return nil, err
-	}
-	region, err := s.regionRepo.GetBySlug(ctx, slug)
-	if err != nil {
-		return nil, err
-	}
-	if region == nil {
-		return nil, errors.ErrNotFound
-	}
-	return region, nil
-}
-
-func (s *LocationService) GetDistrictsByRegionSlug(ctx context.Context, regionSlug string) ([]models.District, error) {
-	if err := s.validateSlug(regionSlug); err != nil {
-		return nil, err
-	}
-	return s.districtRepo.GetByRegionSlug(ctx, regionSlug)
-}
-
-// District methods
-func (s *LocationService) GetDistrictBySlug(ctx context.Context, slug string) (*models.District, error) {
-	if err := s.validateSlug(slug); err != nil {
-		return nil, err
-	}
-	district, err := s.districtRepo.GetBySlug(ctx, slug)
-	if err != nil {
-		return nil, err
-	}
-	if district == nil {
-		return nil, errors.ErrNotFound
-	}
-	return district, nil
-}
-
-func (s *LocationService) GetConstituenciesByDistrictSlug(ctx context.Context, districtSlug string) ([]models.Constituency, error) {
-	if err := s.validateSlug(districtSlug); err != nil {
-		return nil, err
-	}
-	return s.constituencyRepo.GetByDistrictSlug(ctx, districtSlug)
-}
-
-// Constituency methods
-func (s *LocationService) GetConstituencyBySlug(ctx context.Context, slug string) (*models.Constituency, error) {
-	if err := s.validateSlug(slug); err != nil {
-		return nil, err
-	}
-	constituency, err := s.constituencyRepo.GetBySlug(ctx, slug)
-	if err != nil {
-		return nil, err
-	}
-	if constituency == nil {
-		return nil, errors.ErrNotFound
-	}
-	return constituency, nil
-}
-
-// City methods
-func (s *LocationService) GetCitiesByDistrictSlug(ctx context.Context, districtSlug string) ([]models.City, error) {
-	if err := s.validateSlug(districtSlug); err != nil {
-		return nil, err
-	}
-	return s.cityRepo.GetByDistrictSlug(ctx, districtSlug)
-}
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghana-location-api/pkg/models"
+	"github.com/ghana-location-api/pkg/dataset"
+	"github.com/ghana-location-api/pkg/errors"
+	"github.com/ghana-location-api/pkg/geo"
+	"github.com/ghana-location-api/pkg/pollingcode"
+)
+
+type LocationService struct {
+	countryRepo      CountryRepository
+	regionRepo       RegionRepository
+	districtRepo     DistrictRepository
+	constituencyRepo ConstituencyRepository
+	cityRepo         CityRepository
+	pollingRepo      PollingStationRepository
+	searchRepo       SearchRepository
+	lineageRepo      LineageRepository
+	altNameRepo      AlternateNameRepository
+	localizedRepo    LocalizedNameRepository
+	metaRepo         MetaRepository
+
+	versionMu        sync.Mutex
+	version          *models.DatasetVersion
+	versionCheckedAt time.Time
+}
+
+func NewLocationService(
+	countryRepo CountryRepository,
+	regionRepo RegionRepository,
+	districtRepo DistrictRepository,
+	constituencyRepo ConstituencyRepository,
+	cityRepo CityRepository,
+	pollingRepo PollingStationRepository,
+	searchRepo SearchRepository,
+	lineageRepo LineageRepository,
+	altNameRepo AlternateNameRepository,
+	localizedRepo LocalizedNameRepository,
+	metaRepo MetaRepository,
+) *LocationService {
+	return &LocationService{
+		countryRepo:      countryRepo,
+		regionRepo:       regionRepo,
+		districtRepo:     districtRepo,
+		constituencyRepo: constituencyRepo,
+		cityRepo:         cityRepo,
+		pollingRepo:      pollingRepo,
+		searchRepo:       searchRepo,
+		lineageRepo:      lineageRepo,
+		altNameRepo:      altNameRepo,
+		localizedRepo:    localizedRepo,
+		metaRepo:         metaRepo,
+	}
+}
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	minSearchLength    = 2
+
+	defaultNearestLimit = 5
+	maxNearestLimit     = 50
+
+	defaultNearbyRadiusKm = 10.0
+	maxNearbyRadiusKm     = 500.0
+	defaultNearbyLimit    = 20
+	maxNearbyLimit        = 100
+
+	hierarchyPageSize = 1000
+
+	// datasetVersionTTL is how long the dataset version is reused before it
+	// is read again, so a reseed is noticed without a restart.
+	datasetVersionTTL = 30 * time.Second
+)
+
+// DistrictTypes lists the valid values of District.Type.
+var DistrictTypes = []string{"metro", "municipal", "district"}
+
+// SearchTypes lists the hierarchy levels that can be searched.
+var SearchTypes = []string{"region", "district", "constituency", "city"}
+
+// validateSlug rejects slugs without a letter or digit, which can name
+// nothing. Other slugs that are not in canonical form may still be aliases.
+func (s *LocationService) validateSlug(slug string) error {
+	if dataset.Slugify(slug) == "" {
+		return errors.ErrInvalidSlug
+	}
+	return nil
+}
+
+func (s *LocationService) validatePollingStationCode(code string) error {
+	_, err := pollingcode.Parse(code)
+	return err
+}
+
+// Country methods
+func (s *LocationService) GetAllCountries(ctx context.Context, opts models.ListOptions) (*models.List[models.Country], error) {
+	return s.countryRepo.GetAll(ctx, opts)
+}
+
+func (s *LocationService) GetCountryByCode(ctx context.Context, code string) (*models.Country, error) {
+	country, err := s.countryRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if country == nil {
+		return nil, errors.ErrNotFound
+	}
+	return country, nil
+}
+
+// Region methods
+func (s *LocationService) GetAllRegions(ctx context.Context, opts models.ListOptions) (*models.List[models.Region], error) {
+	return s.regionRepo.GetAll(ctx, opts)
+}
+
+func (s *LocationService) GetRegionBySlug(ctx context.Context, slug string) (*models.Region, error) {
+	return lookupSlug(ctx, s, "region", slug, s.regionRepo.GetBySlug)
+}
+
+func (s *LocationService) GetRegionBoundary(ctx context.Context, slug string) (*models.Boundary, error) {
+	return lookupSlug(ctx, s, "region", slug, s.regionRepo.GetBoundary)
+}
+
+func (s *LocationService) GetDistrictsByRegionSlug(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.District], error) {
+	return listBySlug(ctx, s, "region", regionSlug, opts, s.districtRepo.GetByRegionSlug)
+}
+
+// District methods
+func (s *LocationService) GetDistrictBySlug(ctx context.Context, slug string) (*models.District, error) {
+	return lookupSlug(ctx, s, "district", slug, s.districtRepo.GetBySlug)
+}
+
+func (s *LocationService) GetDistrictBoundary(ctx context.Context, slug string) (*models.Boundary, error) {
+	return lookupSlug(ctx, s, "district", slug, s.districtRepo.GetBoundary)
+}
+
+func (s *LocationService) GetConstituenciesByDistrictSlug(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	return listBySlug(ctx, s, "district", districtSlug, opts, s.constituencyRepo.GetByDistrictSlug)
+}
+
+func (s *LocationService) ListDistricts(ctx context.Context, filter models.DistrictFilter, opts models.ListOptions) (*models.List[models.District], error) {
+	var err error
+	if filter.RegionSlug, err = s.canonicalSlug(ctx, "region", filter.RegionSlug); err != nil {
+		return nil, err
+	}
+	if filter.Type != "" && !slices.Contains(DistrictTypes, filter.Type) {
+		return nil, fmt.Errorf("%w: type must be one of %s", errors.ErrInvalidFilter, strings.Join(DistrictTypes, ", "))
+	}
+	return s.districtRepo.List(ctx, filter, opts)
+}
+
+// Lineage methods
+
+// GetDistrictLineage returns the districts a district was split, merged or
+// renamed from and those it was split, merged or renamed into.
+func (s *LocationService) GetDistrictLineage(ctx context.Context, slug string) (*models.Lineage, error) {
+	if _, err := s.GetDistrictBySlug(ctx, slug); err != nil {
+		return nil, err
+	}
+	return s.getLineage(ctx, "district", slug)
+}
+
+// GetConstituencyLineage is GetDistrictLineage for constituencies.
+func (s *LocationService) GetConstituencyLineage(ctx context.Context, slug string) (*models.Lineage, error) {
+	if _, err := s.GetConstituencyBySlug(ctx, slug); err != nil {
+		return nil, err
+	}
+	return s.getLineage(ctx, "constituency", slug)
+}
+
+func (s *LocationService) getLineage(ctx context.Context, kind, slug string) (*models.Lineage, error) {
+	predecessors, err := s.lineageRepo.GetPredecessors(ctx, kind, slug)
+	if err != nil {
+		return nil, err
+	}
+	successors, err := s.lineageRepo.GetSuccessors(ctx, kind, slug)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Lineage{Kind: kind, Slug: slug, Predecessors: predecessors, Successors: successors}, nil
+}
+
+// GetDistrictSuccessors returns the districts that replaced a retired
+// district and are valid on the as_of date of ctx, or today. Successors that
+// were themselves retired are followed to the districts that replaced them.
+func (s *LocationService) GetDistrictSuccessors(ctx context.Context, slug string) ([]models.LineageLink, error) {
+	date := models.ValidityDate(ctx)
+	var successors []models.LineageLink
+	seen := map[string]bool{slug: true}
+	for queue := []string{slug}; len(queue) > 0; queue = queue[1:] {
+		links, err := s.lineageRepo.GetSuccessors(ctx, "district", queue[0])
+		if err != nil {
+			return nil, err
+		}
+		for _, link := range links {
+			if seen[link.Slug] {
+				continue
+			}
+			seen[link.Slug] = true
+
+			district, err := s.districtRepo.GetBySlug(ctx, link.Slug)
+			if err != nil {
+				return nil, err
+			}
+			if district != nil && district.ValidOn(date) {
+				successors = append(successors, link)
+			} else {
+				queue = append(queue, link.Slug)
+			}
+		}
+	}
+	return successors, nil
+}
+
+// Constituency methods
+func (s *LocationService) GetConstituencyBySlug(ctx context.Context, slug string) (*models.Constituency, error) {
+	return lookupSlug(ctx, s, "constituency", slug, s.constituencyRepo.GetBySlug)
+}
+
+// GetConstituencyDetail returns a constituency with its district, when known,
+// and its region.
+func (s *LocationService) GetConstituencyDetail(ctx context.Context, slug string) (*models.ConstituencyDetail, error) {
+	constituency, err := s.GetConstituencyBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.ConstituencyDetail{Constituency: *constituency}
+	if constituency.DistrictID != nil {
+		if detail.District, err = s.districtRepo.GetByID(ctx, *constituency.DistrictID); err != nil {
+			return nil, err
+		}
+	}
+	if constituency.RegionID != nil {
+		if detail.Region, err = s.regionRepo.GetByID(ctx, *constituency.RegionID); err != nil {
+			return nil, err
+		}
+	}
+	return detail, nil
+}
+
+func (s *LocationService) GetConstituenciesByRegionSlug(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	return listBySlug(ctx, s, "region", regionSlug, opts, s.constituencyRepo.GetByRegionSlug)
+}
+
+func (s *LocationService) ListConstituencies(ctx context.Context, filter models.ConstituencyFilter, opts models.ListOptions) (*models.List[models.Constituency], error) {
+	var err error
+	if filter.RegionSlug, err = s.canonicalSlug(ctx, "region", filter.RegionSlug); err != nil {
+		return nil, err
+	}
+	if filter.DistrictSlug, err = s.canonicalSlug(ctx, "district", filter.DistrictSlug); err != nil {
+		return nil, err
+	}
+	return s.constituencyRepo.List(ctx, filter, opts)
+}
+
+// City methods
+func (s *LocationService) GetCityBySlug(ctx context.Context, slug string) (*models.City, error) {
+	return lookupSlug(ctx, s, "city", slug, s.cityRepo.GetBySlug)
+}
+
+// GetCityDetail returns a city with its district and region.
+func (s *LocationService) GetCityDetail(ctx context.Context, slug string) (*models.CityDetail, error) {
+	city, err := s.GetCityBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.CityDetail{City: *city}
+	if detail.District, err = s.districtRepo.GetByID(ctx, city.DistrictID); err != nil {
+		return nil, err
+	}
+	if detail.District != nil {
+		if detail.Region, err = s.regionRepo.GetByID(ctx, detail.District.RegionID); err != nil {
+			return nil, err
+		}
+	}
+	return detail, nil
+}
+
+func (s *LocationService) GetCitiesByDistrictSlug(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.City], error) {
+	return listBySlug(ctx, s, "district", districtSlug, opts, s.cityRepo.GetByDistrictSlug)
+}
+
+func (s *LocationService) ListCities(ctx context.Context, filter models.CityFilter, opts models.ListOptions) (*models.List[models.City], error) {
+	var err error
+	if filter.RegionSlug, err = s.canonicalSlug(ctx, "region", filter.RegionSlug); err != nil {
+		return nil, err
+	}
+	if filter.DistrictSlug, err = s.canonicalSlug(ctx, "district", filter.DistrictSlug); err != nil {
+		return nil, err
+	}
+	return s.cityRepo.List(ctx, filter, opts)
+}
+
+// Hierarchy methods
+
+// GetHierarchy returns every region with its districts and their
+// constituencies, and cities too when includeCities is set. Constituencies
+// not linked to a district are listed under their region. Each level is
+// fetched with one repository call regardless of the number of parents.
+func (s *LocationService) GetHierarchy(ctx context.Context, includeCities bool) (*models.Hierarchy, error) {
+	country, err := s.countryRepo.GetByCode(ctx, "GH")
+	if err != nil {
+		return nil, err
+	}
+
+	var regions []models.Region
+	opts := models.ListOptions{Limit: hierarchyPageSize}
+	for {
+		page, err := s.regionRepo.GetAll(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		regions = append(regions, page.Data...)
+		if page.NextCursor == nil {
+			break
+		}
+		opts.Cursor = *page.NextCursor
+	}
+
+	nodes, err := s.expandRegions(ctx, regions, models.Expand{Districts: true, Constituencies: true, Cities: includeCities})
+	if err != nil {
+		return nil, err
+	}
+	return &models.Hierarchy{Country: country, Regions: nodes}, nil
+}
+
+// GetRegionTree returns a region with the child collections selected by expand.
+// Expanding constituencies or cities implies districts, under which they nest;
+// constituencies not linked to a district nest under the region.
+func (s *LocationService) GetRegionTree(ctx context.Context, slug string, expand models.Expand) (*models.RegionNode, error) {
+	region, err := s.GetRegionBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	expand.Districts = expand.Districts || expand.Constituencies || expand.Cities
+	nodes, err := s.expandRegions(ctx, []models.Region{*region}, expand)
+	if err != nil {
+		return nil, err
+	}
+	return &nodes[0], nil
+}
+
+// GetDistrictTree returns a district with the child collections selected by expand.
+func (s *LocationService) GetDistrictTree(ctx context.Context, slug string, expand models.Expand) (*models.DistrictNode, error) {
+	district, err := s.GetDistrictBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := s.expandDistricts(ctx, []models.District{*district}, expand)
+	if err != nil {
+		return nil, err
+	}
+	return &nodes[0], nil
+}
+
+func (s *LocationService) expandRegions(ctx context.Context, regions []models.Region, expand models.Expand) ([]models.RegionNode, error) {
+	nodes := make([]models.RegionNode, len(regions))
+	for i, region := range regions {
+		nodes[i].Region = region
+	}
+	if !expand.Districts || len(regions) == 0 {
+		return nodes, nil
+	}
+
+	regionIDs := make([]string, len(regions))
+	for i, region := range regions {
+		regionIDs[i] = region.ID
+	}
+	districts, err := s.districtRepo.GetByRegionIDs(ctx, regionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expand every district in one pass, then hand them back to their regions.
+	var all []models.District
+	for _, region := range regions {
+		all = append(all, districts[region.ID]...)
+	}
+	expanded, err := s.expandDistricts(ctx, all, expand)
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodes {
+		n := len(districts[nodes[i].ID])
+		nodes[i].Districts, expanded = expanded[:n:n], expanded[n:]
+	}
+
+	if expand.Constituencies {
+		constituencies, err := s.constituencyRepo.GetWithoutDistrictByRegionIDs(ctx, regionIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range nodes {
+			nodes[i].Constituencies = nonNil(constituencies[nodes[i].ID])
+		}
+	}
+
+	return nodes, nil
+}
+
+func (s *LocationService) expandDistricts(ctx context.Context, districts []models.District, expand models.Expand) ([]models.DistrictNode, error) {
+	nodes := make([]models.DistrictNode, len(districts))
+	districtIDs := make([]string, len(districts))
+	for i, district := range districts {
+		nodes[i].District = district
+		districtIDs[i] = district.ID
+	}
+	if len(districts) == 0 {
+		return nodes, nil
+	}
+
+	if expand.Constituencies {
+		constituencies, err := s.constituencyRepo.GetByDistrictIDs(ctx, districtIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range nodes {
+			nodes[i].Constituencies = nonNil(constituencies[nodes[i].ID])
+		}
+	}
+	if expand.Cities {
+		cities, err := s.cityRepo.GetByDistrictIDs(ctx, districtIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range nodes {
+			nodes[i].Cities = nonNil(cities[nodes[i].ID])
+		}
+	}
+
+	return nodes, nil
+}
+
+// nonNil turns a missing collection into an empty one, so expanded but empty
+// collections are encoded as [] rather than left out.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// ReverseGeocode resolves a point to the region and district whose boundaries
+// contain it and the cities nearest to it.
+func (s *LocationService) ReverseGeocode(ctx context.Context, lat, lng float64, limit int) (*models.ReverseGeocodeResult, error) {
+	if !geo.ValidPoint(lat, lng) {
+		return nil, errors.ErrInvalidCoordinates
+	}
+	if limit <= 0 {
+		limit = defaultNearestLimit
+	}
+	if limit > maxNearestLimit {
+		limit = maxNearestLimit
+	}
+
+	region, err := s.regionRepo.GetContaining(ctx, lat, lng)
+	if err != nil {
+		return nil, err
+	}
+	district, err := s.districtRepo.GetContaining(ctx, lat, lng)
+	if err != nil {
+		return nil, err
+	}
+	cities, err := s.getNearestCities(ctx, lat, lng, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ReverseGeocodeResult{Region: region, District: district, Cities: cities}, nil
+}
+
+// GetNearbyCities returns the cities within radiusKm of the city with the
+// given slug, nearest first, leaving out the city itself. A radius or limit
+// of zero selects the default.
+func (s *LocationService) GetNearbyCities(ctx context.Context, slug string, radiusKm float64, limit int) (*models.NearbyCities, error) {
+	if radiusKm == 0 {
+		radiusKm = defaultNearbyRadiusKm
+	}
+	if math.IsNaN(radiusKm) || math.IsInf(radiusKm, 0) || radiusKm < 0 || radiusKm > maxNearbyRadiusKm {
+		return nil, fmt.Errorf("%w: radius must be positive and at most %g km", errors.ErrInvalidRadius, maxNearbyRadiusKm)
+	}
+	if limit <= 0 {
+		limit = defaultNearbyLimit
+	}
+	if limit > maxNearbyLimit {
+		limit = maxNearbyLimit
+	}
+
+	city, err := s.GetCityBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !hasCoordinates(city) {
+		return nil, errors.ErrNoCoordinates
+	}
+
+	// One extra city is fetched, as the city itself is always the nearest.
+	nearby, err := s.cityRepo.GetWithinRadius(ctx, *city.Lat, *city.Lng, radiusKm, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	nearby = slices.DeleteFunc(nearby, func(n models.NearestCity) bool { return n.City.ID == city.ID })
+	if len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	if err := s.attachConstituencies(ctx, nearby); err != nil {
+		return nil, err
+	}
+
+	return &models.NearbyCities{City: *city, RadiusKm: radiusKm, Cities: nearby}, nil
+}
+
+// GetCityDistance returns the great-circle distance between two cities.
+func (s *LocationService) GetCityDistance(ctx context.Context, fromSlug, toSlug string) (*models.CityDistance, error) {
+	from, err := s.GetCityBySlug(ctx, fromSlug)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.GetCityBySlug(ctx, toSlug)
+	if err != nil {
+		return nil, err
+	}
+	if !hasCoordinates(from) || !hasCoordinates(to) {
+		return nil, errors.ErrNoCoordinates
+	}
+
+	return &models.CityDistance{
+		From:       *from,
+		To:         *to,
+		DistanceKm: geo.HaversineKm(*from.Lat, *from.Lng, *to.Lat, *to.Lng),
+	}, nil
+}
+
+// hasCoordinates reports whether the city has a usable position. Coordinates
+// that are not a valid point are treated as missing, as distances computed
+// from them could not be encoded.
+func hasCoordinates(city *models.City) bool {
+	return city.Lat != nil && city.Lng != nil && geo.ValidPoint(*city.Lat, *city.Lng)
+}
+
+func (s *LocationService) getNearestCities(ctx context.Context, lat, lng float64, limit int) ([]models.NearestCity, error) {
+	cities, err := s.cityRepo.GetNearest(ctx, lat, lng, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachConstituencies(ctx, cities); err != nil {
+		return nil, err
+	}
+	return cities, nil
+}
+
+// attachConstituencies fills in the constituencies of each city's district.
+func (s *LocationService) attachConstituencies(ctx context.Context, cities []models.NearestCity) error {
+	if len(cities) == 0 {
+		return nil
+	}
+
+	districtIDs := make([]string, 0, len(cities))
+	for _, city := range cities {
+		districtIDs = append(districtIDs, city.District.ID)
+	}
+	constituencies, err := s.constituencyRepo.GetByDistrictIDs(ctx, districtIDs)
+	if err != nil {
+		return err
+	}
+	for i := range cities {
+		if c, ok := constituencies[cities[i].District.ID]; ok {
+			cities[i].Constituencies = c
+		}
+	}
+
+	return nil
+}
+
+// Polling station methods
+func (s *LocationService) GetPollingStationsByConstituencySlug(ctx context.Context, constituencySlug string, opts models.ListOptions) (*models.List[models.PollingStation], error) {
+	return listBySlug(ctx, s, "constituency", constituencySlug, opts, s.pollingRepo.GetByConstituencySlug)
+}
+
+func (s *LocationService) GetPollingStationByCode(ctx context.Context, code string) (*models.PollingStation, error) {
+	code = strings.ToUpper(code)
+	if err := s.validatePollingStationCode(code); err != nil {
+		return nil, err
+	}
+	station, err := s.pollingRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if station == nil {
+		return nil, errors.ErrNotFound
+	}
+	return station, nil
+}
+
+// DecodePollingStationCode splits a polling station code into its parts and
+// looks up the region and constituency they refer to, and the station itself.
+func (s *LocationService) DecodePollingStationCode(ctx context.Context, code string) (*models.PollingStationCode, error) {
+	parsed, err := pollingcode.Parse(code)
+	if err != nil {
+		return nil, err
+	}
+
+	region, err := s.regionRepo.GetByECLetter(ctx, parsed.RegionLetter)
+	if err != nil {
+		return nil, err
+	}
+	if region == nil {
+		return nil, fmt.Errorf("%w: no region has the letter %s", errors.ErrInvalidCode, parsed.RegionLetter)
+	}
+
+	station, err := s.pollingRepo.GetByCode(ctx, parsed.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// A known station records its constituency. The constituency number
+	// alone is ambiguous once a constituency has been split, as the new
+	// constituencies keep its number.
+	var constituency *models.Constituency
+	if station != nil && station.ConstituencyID != nil {
+		constituency, err = s.constituencyRepo.GetByID(ctx, *station.ConstituencyID)
+	} else {
+		constituency, err = s.constituencyRepo.GetByECNumber(ctx, region.ID, parsed.Constituency)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PollingStationCode{
+		Code:               parsed.Code,
+		RegionLetter:       parsed.RegionLetter,
+		ConstituencyNumber: parsed.Constituency,
+		ElectoralArea:      parsed.ElectoralArea,
+		StationNumber:      parsed.Station,
+		Split:              parsed.Split,
+		Region:             *region,
+		Constituency:       constituency,
+		PollingStation:     station,
+	}, nil
+}
+
+// Search methods
+func (s *LocationService) Search(ctx context.Context, query string, types []string, limit int) ([]models.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if len([]rune(query)) < minSearchLength {
+		return nil, fmt.Errorf("%w: q must be at least %d characters", errors.ErrInvalidQuery, minSearchLength)
+	}
+
+	if len(types) == 0 {
+		types = SearchTypes
+	}
+	for _, t := range types {
+		if !slices.Contains(SearchTypes, t) {
+			return nil, fmt.Errorf("%w: type must be one of %s", errors.ErrInvalidQuery, strings.Join(SearchTypes, ", "))
+		}
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	return s.searchRepo.Search(ctx, query, types, limit)
+}
+
+// Meta methods
+
+// DatasetVersion returns the version of the data being served, or nil when
+// no seed has been recorded. It is cached for datasetVersionTTL.
+func (s *LocationService) DatasetVersion(ctx context.Context) (*models.DatasetVersion, error) {
+	s.versionMu.Lock()
+	defer s.versionMu.Unlock()
+
+	if !s.versionCheckedAt.IsZero() && time.Since(s.versionCheckedAt) < datasetVersionTTL {
+		return s.version, nil
+	}
+	version, err := s.metaRepo.GetDatasetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.version, s.versionCheckedAt = version, time.Now()
+	return version, nil
+}
+
+// GetMeta returns the dataset version and the number of locations of each kind.
+func (s *LocationService) GetMeta(ctx context.Context) (*models.Meta, error) {
+	version, err := s.DatasetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.metaRepo.GetCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := s.Languages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Meta{Dataset: version, Counts: *counts, Languages: languages}, nil
+}
+
+// Languages returns the languages names can be shown in: English, then the
+// languages with localized names in code order.
+func (s *LocationService) Languages(ctx context.Context) ([]string, error) {
+	languages, err := s.localizedRepo.GetLanguages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{models.DefaultLanguage}, languages...), nil
+}
+
+// LocalizedNames returns the names in lang, keyed by the kind and slug of the
+// record they name. Records missing from it keep their English name.
+func (s *LocationService) LocalizedNames(ctx context.Context, lang string) (map[models.NameKey]string, error) {
+	names := make(map[models.NameKey]string)
+	if lang == models.DefaultLanguage {
+		return names, nil
+	}
+	localized, err := s.localizedRepo.GetByLanguage(ctx, lang)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range localized {
+		names[models.NameKey{Kind: name.Kind, Slug: name.Slug}] = name.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+func TestDecodePollingStationCode(t *testing.T) {
+	s := newService(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		code         string
+		constituency string // empty when none is expected
+		station      bool
+	}{
+		// Constituencies split from one another share their prefix; the
+		// station's own constituency tells them apart.
+		{"B020101", "cape-coast-south", true},
+		{"B023101", "cape-coast-north", true},
+		{"C010701", "domeabra-obom", true},
+		{"C150101A", "okaikwei-central", true},
+		{"C231101A", "tema-central", true},
+		{"M132401", "tamale-central", true},
+		{"M132301A", "tamale-south", true},
+		{"M141301A", "tamale-north", true},
+		{"M140101A", "sagnarigu", true},
+		{"b020101", "cape-coast-south", true},
+
+		// Without a station, only a prefix of one constituency decodes.
+		{"B019901", "komenda-edina-eguafo-abrem", false},
+		{"B029901", "", false},
+		{"M149901", "", false},
+		{"B999901", "", false},
+	}
+	for _, tt := range tests {
+		decoded, err := s.DecodePollingStationCode(ctx, tt.code)
+		if err != nil {
+			t.Errorf("DecodePollingStationCode(%s): %v", tt.code, err)
+			continue
+		}
+		got := ""
+		if decoded.Constituency != nil {
+			got = decoded.Constituency.Slug
+		}
+		if got != tt.constituency {
+			t.Errorf("DecodePollingStationCode(%s) constituency = %q, want %q", tt.code, got, tt.constituency)
+		}
+		if (decoded.PollingStation != nil) != tt.station {
+			t.Errorf("DecodePollingStationCode(%s) station = %+v, want found %v", tt.code, decoded.PollingStation, tt.station)
+		}
+	}
+}
+
+// TestDecodeEveryPollingStation checks that every station's code decodes to
+// the constituency the station is filed under.
+func TestDecodeEveryPollingStation(t *testing.T) {
+	s := newService(t)
+	ctx := context.Background()
+
+	constituencies, err := s.ListConstituencies(ctx, models.ConstituencyFilter{}, models.ListOptions{Limit: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for _, constituency := range constituencies.Data {
+		opts := models.ListOptions{Limit: 1000}
+		for {
+			stations, err := s.GetPollingStationsByConstituencySlug(ctx, constituency.Slug, opts)
+			if err != nil {
+				t.Fatalf("polling stations of %s: %v", constituency.Slug, err)
+			}
+			for _, station := range stations.Data {
+				count++
+				decoded, err := s.DecodePollingStationCode(ctx, station.Code)
+				if err != nil {
+					t.Errorf("DecodePollingStationCode(%s): %v", station.Code, err)
+					continue
+				}
+				if decoded.Constituency == nil || decoded.Constituency.ID != constituency.ID {
+					t.Errorf("DecodePollingStationCode(%s) constituency = %+v, want %s", station.Code, decoded.Constituency, constituency.Slug)
+				}
+			}
+			if stations.NextCursor == nil {
+				break
+			}
+			opts.Cursor = *stations.NextCursor
+		}
+	}
+	if count == 0 {
+		t.Error("no polling stations decoded")
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ghana-location-api/pkg/models"
+)
+
+// The repository interfaces below are implemented by the PostgreSQL
+// repositories in pkg/repositories and by the embedded dataset in
+// pkg/repositories/memory. Single-item lookups return nil, nil when nothing matches.
+
+type CountryRepository interface {
+	GetAll(ctx context.Context, opts models.ListOptions) (*models.List[models.Country], error)
+	GetByCode(ctx context.Context, code string) (*models.Country, error)
+}
+
+type RegionRepository interface {
+	GetAll(ctx context.Context, opts models.ListOptions) (*models.List[models.Region], error)
+	GetBySlug(ctx context.Context, slug string) (*models.Region, error)
+	GetByID(ctx context.Context, id string) (*models.Region, error)
+	GetByECLetter(ctx context.Context, letter string) (*models.Region, error)
+	GetBoundary(ctx context.Context, slug string) (*models.Boundary, error)
+	GetContaining(ctx context.Context, lat, lng float64) (*models.Region, error)
+}
+
+type DistrictRepository interface {
+	GetBySlug(ctx context.Context, slug string) (*models.District, error)
+	GetByID(ctx context.Context, id string) (*models.District, error)
+	GetByRegionSlug(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.District], error)
+	GetByRegionIDs(ctx context.Context, regionIDs []string) (map[string][]models.District, error)
+	List(ctx context.Context, filter models.DistrictFilter, opts models.ListOptions) (*models.List[models.District], error)
+	GetBoundary(ctx context.Context, slug string) (*models.Boundary, error)
+	GetContaining(ctx context.Context, lat, lng float64) (*models.District, error)
+}
+
+type ConstituencyRepository interface {
+	GetBySlug(ctx context.Context, slug string) (*models.Constituency, error)
+	GetByID(ctx context.Context, id string) (*models.Constituency, error)
+	GetByECNumber(ctx context.Context, regionID string, number int) (*models.Constituency, error)
+	GetByDistrictSlug(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.Constituency], error)
+	GetByRegionSlug(ctx context.Context, regionSlug string, opts models.ListOptions) (*models.List[models.Constituency], error)
+	GetByDistrictIDs(ctx context.Context, districtIDs []string) (map[string][]models.Constituency, error)
+	GetWithoutDistrictByRegionIDs(ctx context.Context, regionIDs []string) (map[string][]models.Constituency, error)
+	List(ctx context.Context, filter models.ConstituencyFilter, opts models.ListOptions) (*models.List[models.Constituency], error)
+}
+
+type CityRepository interface {
+	GetBySlug(ctx context.Context, slug string) (*models.City, error)
+	GetByDistrictSlug(ctx context.Context, districtSlug string, opts models.ListOptions) (*models.List[models.City], error)
+	GetByDistrictIDs(ctx context.Context, districtIDs []string) (map[string][]models.City, error)
+	List(ctx context.Context, filter models.CityFilter, opts models.ListOptions) (*models.List[models.City], error)
+	GetNearest(ctx context.Context, lat, lng float64, limit int) ([]models.NearestCity, error)
+	GetWithinRadius(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]models.NearestCity, error)
+}
+
+type PollingStationRepository interface {
+	GetByCode(ctx context.Context, code string) (*models.PollingStation, error)
+	GetByConstituencySlug(ctx context.Context, constituencySlug string, opts models.ListOptions) (*models.List[models.PollingStation], error)
+}
+
+type SearchRepository interface {
+	Search(ctx context.Context, query string, types []string, limit int) ([]models.SearchResult, error)
+}
+
+// AlternateNameRepository finds the alternate name of kind whose key, the
+// name slugified with dataset.Slugify, is key.
+type AlternateNameRepository interface {
+	GetByKey(ctx context.Context, kind, key string) (*models.AlternateName, error)
+}
+
+// LineageRepository returns the lineage links of a district or constituency,
+// oldest first. kind is "district" or "constituency".
+type LineageRepository interface {
+	GetPredecessors(ctx context.Context, kind, slug string) ([]models.LineageLink, error)
+	GetSuccessors(ctx context.Context, kind, slug string) ([]models.LineageLink, error)
+}
+
+// LocalizedNameRepository returns the names of regions, districts,
+// constituencies and cities in languages other than English. GetLanguages
+// lists the languages that have any, in code order.
+type LocalizedNameRepository interface {
+	GetByLanguage(ctx context.Context, lang string) ([]models.LocalizedName, error)
+	GetLanguages(ctx context.Context) ([]string, error)
+}
+
+type MetaRepository interface {
+	GetDatasetVersion(ctx context.Context) (*models.DatasetVersion, error)
+	GetCounts(ctx context.Context) (*models.Counts, error)
+}
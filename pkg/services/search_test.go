@@ -0,0 +1,37 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	s := newService(t)
+	ctx := context.Background()
+
+	results, err := s.Search(ctx, "kumasi", nil, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) == 0 || !strings.HasPrefix(results[0].Name, "Kumasi") {
+		t.Errorf("Search(kumasi) = %+v, want a name starting with Kumasi first", results)
+	}
+
+	// LIKE wildcards in the query are matched literally.
+	for _, query := range []string{"%%", "__", `%\`} {
+		results, err := s.Search(ctx, query, nil, 5)
+		if err != nil || len(results) != 0 {
+			t.Errorf("Search(%q) = %d results, %v, want none", query, len(results), err)
+		}
+	}
+	results, err = s.Search(ctx, "k_masi", nil, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range results {
+		if result.Score >= 1 {
+			t.Errorf("Search(k_masi) ranked %s as a prefix match", result.Name)
+		}
+	}
+}